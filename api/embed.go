@@ -0,0 +1,15 @@
+// Package api embeds the OpenAPI specifications generated from the proto
+// files so the server can publish them next to the REST gateway.
+package api
+
+import _ "embed"
+
+// OpenAPIv2 is the Swagger 2.0 document for every service.
+//
+//go:embed openapiv2/task.swagger.json
+var OpenAPIv2 []byte
+
+// OpenAPIv3 is the OpenAPI 3 document for every service.
+//
+//go:embed openapiv3/openapi.yaml
+var OpenAPIv3 []byte
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newCalendarCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "Publish tasks as iCalendar feeds and import VTODO entries",
+		Long: `Publish the tasks of a user as an iCalendar (ICS) feed that calendar
+apps can subscribe to, and create tasks from the VTODO entries of an ICS
+file.
+
+Tasks have no due date nor priority of their own: they are read from the
+due:YYYY-MM-DD and pri:A tags of the title, as in the todo commands, and
+written back there on import. iCalendar has nine priorities, so pri:A to
+pri:I become PRIORITY 1 to 9 and pri:J to pri:Z are left in the summary.`,
+	}
+
+	feed := &cobra.Command{
+		Use:   "feed",
+		Short: "Manage the secret URLs of the calendar feeds",
+	}
+	feed.AddCommand(
+		newCalendarFeedCreateCommand(opts),
+		newCalendarFeedListCommand(opts),
+		newCalendarFeedRevokeCommand(opts),
+	)
+
+	cmd.AddCommand(feed, newCalendarImportCommand(opts))
+	return cmd
+}
+
+func newCalendarFeedCreateCommand(opts *globalOptions) *cobra.Command {
+	var (
+		includeShared bool
+		baseURL       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a feed of your tasks and print its secret URL",
+		Long: `Create a feed of your tasks. Its URL carries a secret token, shown only
+once: anyone with the URL can read the tasks, until the feed is revoked.`,
+		Example: `  taskctl calendar feed create phone --base-url https://tasks.example.com`,
+		Args:    args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				ctx, cancel := opts.rpcContext(cmd.Context())
+				defer cancel()
+
+				resp, err := client.calendar.CreateCalendarFeed(ctx, &taskpb.CreateCalendarFeedRequest{
+					Name:          names[0],
+					IncludeShared: includeShared,
+				})
+				if err != nil {
+					return err
+				}
+				if baseURL != "" {
+					resp.Path = strings.TrimSuffix(baseURL, "/") + resp.Path
+				}
+
+				if printed, err := printMessage(cmd, opts, resp); printed || err != nil {
+					return err
+				}
+				w := cmd.OutOrStdout()
+				fmt.Fprintf(w, "created feed %s (%s)\n", resp.Feed.Id, resp.Feed.Name)
+				fmt.Fprintf(w, "url: %s\n", resp.Path)
+				fmt.Fprintln(cmd.ErrOrStderr(), "the URL is shown only once; anyone with it can read your tasks until the feed is revoked")
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeShared, "include-shared", false, "include tasks shared with you")
+	cmd.Flags().StringVar(&baseURL, "base-url", "", "HTTP address of the server, to print the full URL (e.g. https://tasks.example.com)")
+	return cmd
+}
+
+func newCalendarFeedListCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List your calendar feeds",
+		Args:  args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				ctx, cancel := opts.rpcContext(cmd.Context())
+				defer cancel()
+
+				resp, err := client.calendar.ListCalendarFeeds(ctx, &taskpb.ListCalendarFeedsRequest{})
+				if err != nil {
+					return err
+				}
+
+				if printed, err := printMessage(cmd, opts, resp); printed || err != nil {
+					return err
+				}
+				tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSHARED\tCREATED\tREVOKED")
+				for _, feed := range resp.Feeds {
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%s\t%s\n", feed.Id, feed.Name, feed.Prefix, feed.IncludeShared,
+						formatFeedTime(feed.CreatedAt), formatFeedTime(feed.RevokedAt))
+				}
+				return tw.Flush()
+			})
+		},
+	}
+}
+
+func newCalendarFeedRevokeCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke ID",
+		Short: "Revoke a calendar feed, invalidating its URL",
+		Args:  args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, ids []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				ctx, cancel := opts.rpcContext(cmd.Context())
+				defer cancel()
+
+				if _, err := client.calendar.RevokeCalendarFeed(ctx, &taskpb.RevokeCalendarFeedRequest{Id: ids[0]}); err != nil {
+					return err
+				}
+				if opts.output.format == outputText {
+					fmt.Fprintf(cmd.OutOrStdout(), "revoked %s\n", ids[0])
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newCalendarImportCommand(opts *globalOptions) *cobra.Command {
+	var userID string
+
+	cmd := &cobra.Command{
+		Use:   "import FILE | -",
+		Short: "Create a task for every VTODO of an ICS file",
+		Long: `Create a task for every VTODO entry of an iCalendar file. Events and
+other components are ignored. Entries that fail do not stop the import: a
+summary of created and failed entries is printed at the end.`,
+		Example: `  taskctl calendar import reminders.ics`,
+		Args:    args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, files []string) error {
+			in := cmd.InOrStdin()
+			if files[0] != "-" {
+				file, err := os.Open(files[0])
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				in = file
+			}
+			data, err := io.ReadAll(in)
+			if err != nil {
+				return err
+			}
+
+			return withClient(opts, func(client *TaskClient) error {
+				ctx, cancel := opts.rpcContext(cmd.Context())
+				defer cancel()
+
+				summary, err := client.calendar.ImportCalendar(ctx, &taskpb.ImportCalendarRequest{
+					UserId: firstNonEmpty(userID, opts.defaultUser),
+					Ics:    string(data),
+				})
+				if err != nil {
+					return err
+				}
+				return printImportSummary(cmd, opts, summary, "entry", int(summary.Created+summary.Skipped+summary.Failed))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the tasks (defaults to the profile user, then the authenticated user)")
+	return cmd
+}
+
+// printMessage prints msg when the output format is JSON or YAML, and
+// reports whether it did so.
+func printMessage(cmd *cobra.Command, opts *globalOptions, msg proto.Message) (bool, error) {
+	if opts.output.format != outputJSON && opts.output.format != outputYAML {
+		return false, nil
+	}
+	data, err := taskMarshaler.Marshal(msg)
+	if err != nil {
+		return true, err
+	}
+	return true, opts.output.encode(cmd.OutOrStdout(), data)
+}
+
+func formatFeedTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Local().Format(time.DateTime)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskclient"
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// minIDPrefix is the shortest ID prefix accepted in place of a full ID,
+// as with git short hashes.
+const minIDPrefix = 4
+
+// idResolver expands short ID prefixes into full task IDs. The tasks of
+// the user are listed once, on the first prefix that needs it.
+type idResolver struct {
+	client *TaskClient
+	opts   *globalOptions
+	tasks  []*taskpb.Task
+	loaded bool
+}
+
+func newIDResolver(client *TaskClient, opts *globalOptions) *idResolver {
+	return &idResolver{client: client, opts: opts}
+}
+
+// resolve returns id unchanged when it is a full UUID. Otherwise it must be
+// the prefix of exactly one task visible to the user.
+func (r *idResolver) resolve(ctx context.Context, id string) (string, error) {
+	if _, err := uuid.FromString(id); err == nil {
+		return id, nil
+	}
+
+	prefix := strings.ToLower(id)
+	if len(prefix) < minIDPrefix || strings.Trim(prefix, "0123456789abcdef-") != "" {
+		return "", usageErrorf("invalid task ID %q: expected a UUID or a prefix of at least %d hex characters", id, minIDPrefix)
+	}
+
+	if !r.loaded {
+		tasks, err := listUserTasks(ctx, r.client, r.opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve ID prefix %q: %w", id, err)
+		}
+		r.tasks, r.loaded = tasks, true
+	}
+
+	var matches []*taskpb.Task
+	for _, task := range r.tasks {
+		if strings.HasPrefix(task.Id, prefix) {
+			matches = append(matches, task)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", status.Errorf(codes.NotFound, "no task matches ID prefix %q", id)
+	case 1:
+		return matches[0].Id, nil
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, task := range matches {
+		candidates = append(candidates, fmt.Sprintf("  %s  %s", task.Id, task.Title))
+	}
+	return "", usageErrorf("ID prefix %q is ambiguous, candidates:\n%s", id, strings.Join(candidates, "\n"))
+}
+
+// listUserTasks lists the tasks of the default user, including the ones
+// shared with them, which are the tasks short IDs and completion refer to.
+func listUserTasks(ctx context.Context, client *TaskClient, opts *globalOptions) ([]*taskpb.Task, error) {
+	return taskclient.Collect(client.sdk.ListTasksByUser(ctx, opts.defaultUser, taskclient.IncludeShared()))
+}
+
+// completeTaskIDs completes task IDs, showing the titles as descriptions in
+// the shells that support them. IDs already on the command line are left out.
+func completeTaskIDs(opts *globalOptions) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// La completación no ejecuta PersistentPreRunE
+		if err := opts.applyProfile(cmd); err != nil {
+			cobra.CompDebugln(err.Error(), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var tasks []*taskpb.Task
+		err := withClient(opts, func(client *TaskClient) error {
+			var err error
+			tasks, err = listUserTasks(cmd.Context(), client, opts)
+			return err
+		})
+		if err != nil {
+			cobra.CompDebugln(errorMessage(err), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		prefix := strings.ToLower(toComplete)
+		var completions []string
+		for _, task := range tasks {
+			if strings.HasPrefix(task.Id, prefix) && !slices.Contains(args, task.Id) {
+				completions = append(completions, task.Id+"\t"+completionDescription(task))
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeFirstTaskID completes the ID of commands taking a single task.
+func completeFirstTaskID(opts *globalOptions) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	complete := completeTaskIDs(opts)
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+func completionDescription(task *taskpb.Task) string {
+	title := strings.Join(strings.Fields(task.Title), " ")
+	if task.Completed {
+		return "[done] " + title
+	}
+	return title
+}
+
+// completeProfiles completes the names of the stored profiles.
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := loadClientConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for name, p := range cfg.Profiles {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name+"\t"+p.Address)
+		}
+	}
+	slices.Sort(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The process exit code is the gRPC status code of the failure, so scripts
+// can tell apart e.g. NotFound (5) from PermissionDenied (7). Invalid usage
+// exits with InvalidArgument (3) and other local errors with Unknown (2).
+
+// usageError marks errors caused by invalid flags or arguments.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{fmt.Errorf(format, args...)}
+}
+
+// bulkError summarizes a bulk operation in which some items failed. Its
+// exit code is that of the last failure.
+type bulkError struct {
+	failed, total int
+	last          error
+}
+
+func (e *bulkError) Error() string {
+	return fmt.Sprintf("%d of %d operations failed", e.failed, e.total)
+}
+
+func (e *bulkError) Unwrap() error { return e.last }
+
+// record counts a failure and reports it, unless the operation was the
+// only one and the error will be reported on exit.
+func (e *bulkError) record(cmd *cobra.Command, item string, err error) {
+	e.failed++
+	e.last = err
+	if e.total > 1 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", item, errorMessage(err))
+	}
+}
+
+// err returns nil when every operation succeeded. A single operation
+// reports its own error instead of a summary.
+func (e *bulkError) err() error {
+	switch {
+	case e.failed == 0:
+		return nil
+	case e.total == 1:
+		return e.last
+	default:
+		return e
+	}
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var usage usageError
+	if errors.As(err, &usage) {
+		return int(codes.InvalidArgument)
+	}
+
+	// status.FromError recorre los errores envueltos
+	if st, ok := status.FromError(err); ok {
+		return int(st.Code())
+	}
+	return int(codes.Unknown)
+}
+
+// errorMessage returns the status message of gRPC errors without the
+// "rpc error: code = ... desc =" prefix.
+func errorMessage(err error) string {
+	var bulk *bulkError
+	if errors.As(err, &bulk) {
+		return bulk.Error()
+	}
+	if st, ok := status.FromError(err); ok {
+		return fmt.Sprintf("%s: %s", st.Code(), st.Message())
+	}
+	return err.Error()
+}
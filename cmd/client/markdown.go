@@ -0,0 +1,58 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// markdownItem matches a checklist item: "- [ ] text", "* [x] text"...
+var markdownItem = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] ?(.*)$`)
+
+// markdownID matches the HTML comment holding the task ID, which is not
+// rendered.
+var markdownID = regexp.MustCompile(`\s*<!-- id:(\S+) -->$`)
+
+// parseMarkdownItem parses a checklist item line. The text after the box
+// follows the todo.txt rules, so it may hold a priority and dates.
+func parseMarkdownItem(line string) (item *todoItem, indent int, ok bool) {
+	match := markdownItem.FindStringSubmatch(line)
+	if match == nil {
+		return nil, 0, false
+	}
+
+	item = &todoItem{Completed: match[2] != " "}
+	body := match[3]
+	if id := markdownID.FindStringSubmatch(body); id != nil && isUUID(id[1]) {
+		item.ID = id[1]
+		body = body[:len(body)-len(id[0])]
+	}
+	// La descripción va en las líneas sangradas, no en una etiqueta note:
+	item.parseBody(body, false)
+	return item, len(match[1]), true
+}
+
+// markdown formats the item as a checklist item, followed by its
+// description indented under it.
+func (item *todoItem) markdown(indent string) string {
+	box := "[ ]"
+	if item.Completed {
+		box = "[x]"
+	}
+	line := indent + "- " + box + " " + item.body(false)
+	if item.ID != "" {
+		line += " <!-- id:" + item.ID + " -->"
+	}
+
+	if item.Description == "" {
+		return line
+	}
+	lines := []string{line}
+	for _, descriptionLine := range strings.Split(item.Description, "\n") {
+		if descriptionLine == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, indent+"  "+descriptionLine)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Output formats of the --output flag.
+const (
+	outputText     = "text"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputTable    = "table"
+	outputCSV      = "csv"
+	outputTemplate = "template"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputYAML, outputTable, outputCSV, outputTemplate}
+
+// taskColumns are the fields that can be selected with --columns, named as
+// in the proto definition.
+var taskColumns = []string{"id", "user_id", "title", "description", "completed", "created_at", "updated_at"}
+
+var defaultTableColumns = []string{"id", "user_id", "title", "completed", "updated_at"}
+
+// Los campos se serializan con los nombres del proto para que la salida
+// coincida con la API REST y con los archivos de --from-file
+var taskMarshaler = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+type printer struct {
+	format   string
+	columns  []string
+	template string
+}
+
+func (p *printer) validate() error {
+	switch p.format {
+	case outputText, outputJSON, outputYAML, outputTable, outputCSV:
+	case outputTemplate:
+		if p.template == "" {
+			return usageErrorf("--output template requires --template")
+		}
+		if _, err := template.New("task").Parse(p.template); err != nil {
+			return usageErrorf("invalid --template: %v", err)
+		}
+	default:
+		return usageErrorf("invalid --output %q (valid: %s)", p.format, strings.Join(outputFormats, ", "))
+	}
+
+	for _, column := range p.columns {
+		if !slices.Contains(taskColumns, column) {
+			return usageErrorf("invalid column %q (valid: %s)", column, strings.Join(taskColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// printTask writes a single task; JSON and YAML print an object.
+func (p *printer) printTask(w io.Writer, task *taskpb.Task) error {
+	if p.format == outputJSON || p.format == outputYAML {
+		data, err := taskMarshaler.Marshal(task)
+		if err != nil {
+			return fmt.Errorf("failed to encode task: %w", err)
+		}
+		return p.encode(w, data)
+	}
+	return p.printTasks(w, []*taskpb.Task{task})
+}
+
+// printTasks writes a list of tasks; JSON and YAML print an array.
+func (p *printer) printTasks(w io.Writer, tasks []*taskpb.Task) error {
+	if p.format == outputText {
+		for i, task := range tasks {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			printTaskTo(w, task)
+		}
+		return nil
+	}
+
+	if p.format == outputJSON || p.format == outputYAML {
+		items := make([]json.RawMessage, 0, len(tasks))
+		for _, task := range tasks {
+			data, err := taskMarshaler.Marshal(task)
+			if err != nil {
+				return fmt.Errorf("failed to encode task: %w", err)
+			}
+			items = append(items, data)
+		}
+		data, err := json.Marshal(items)
+		if err != nil {
+			return err
+		}
+		return p.encode(w, data)
+	}
+
+	records := make([]map[string]any, 0, len(tasks))
+	for _, task := range tasks {
+		record, err := taskRecord(task)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	switch p.format {
+	case outputTable:
+		return p.writeTable(w, records)
+	case outputCSV:
+		return p.writeCSV(w, records)
+	default:
+		return p.writeTemplate(w, records)
+	}
+}
+
+// taskRecord converts a task into its protojson representation.
+func taskRecord(task *taskpb.Task) (map[string]any, error) {
+	data, err := taskMarshaler.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to encode task: %w", err)
+	}
+	return record, nil
+}
+
+// encode re-indents protojson output, whose spacing is deliberately unstable,
+// or converts it to YAML keeping the field order of the proto.
+func (p *printer) encode(w io.Writer, data []byte) error {
+	if p.format == outputYAML {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		plainStyle(&doc)
+
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(w)
+	return err
+}
+
+// plainStyle drops the JSON flow style and quoting so the document is
+// emitted as block YAML; the encoder still quotes values that need it.
+func plainStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		plainStyle(child)
+	}
+}
+
+func (p *printer) selectedColumns(defaults []string) []string {
+	if len(p.columns) > 0 {
+		return p.columns
+	}
+	return defaults
+}
+
+func (p *printer) writeTable(w io.Writer, records []map[string]any) error {
+	columns := p.selectedColumns(defaultTableColumns)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, record := range records {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = strings.ReplaceAll(formatValue(record[column]), "\n", " ")
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) writeCSV(w io.Writer, records []map[string]any) error {
+	columns := p.selectedColumns(taskColumns)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, record := range records {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = formatValue(record[column])
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeTemplate renders the template once per task, with the task fields
+// available by their proto names, e.g. {{.id}} {{.title}}.
+func (p *printer) writeTemplate(w io.Writer, records []map[string]any) error {
+	tmpl, err := template.New("task").Option("missingkey=zero").Parse(p.template)
+	if err != nil {
+		return usageErrorf("invalid --template: %v", err)
+	}
+
+	for _, record := range records {
+		// Los campos nulos se muestran vacíos en lugar de "<no value>"
+		for key, value := range record {
+			if value == nil {
+				record[key] = ""
+			}
+		}
+		if err := tmpl.Execute(w, record); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(p.template, "\n") {
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
+
+func formatValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
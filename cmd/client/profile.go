@@ -0,0 +1,528 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// Los perfiles se guardan en config.yaml y las credenciales aparte, en
+// credentials.yaml, que debe ser legible solo por su dueño (como ~/.ssh).
+const (
+	configFileName      = "config.yaml"
+	credentialsFileName = "credentials.yaml"
+)
+
+// clientConfig is the content of ~/.config/taskctl/config.yaml.
+type clientConfig struct {
+	CurrentProfile string              `yaml:"current_profile,omitempty"`
+	Profiles       map[string]*profile `yaml:"profiles,omitempty"`
+}
+
+// profile holds the connection settings of one environment.
+type profile struct {
+	Address     string     `yaml:"address,omitempty"`
+	TLS         profileTLS `yaml:"tls,omitempty"`
+	DefaultUser string     `yaml:"default_user,omitempty"`
+}
+
+type profileTLS struct {
+	Enabled    bool   `yaml:"enabled,omitempty"`
+	CACert     string `yaml:"ca_cert,omitempty"`
+	Cert       string `yaml:"cert,omitempty"`
+	Key        string `yaml:"key,omitempty"`
+	ServerName string `yaml:"server_name,omitempty"`
+	Insecure   bool   `yaml:"insecure,omitempty"`
+}
+
+// credentialsFile is the content of ~/.config/taskctl/credentials.yaml,
+// keyed by profile name.
+type credentialsFile struct {
+	Credentials map[string]*credentials `yaml:"credentials,omitempty"`
+}
+
+type credentials struct {
+	Token  string `yaml:"token,omitempty"`
+	APIKey string `yaml:"api_key,omitempty"`
+}
+
+// configDir returns the taskctl directory under the user config directory,
+// i.e. $XDG_CONFIG_HOME/taskctl or ~/.config/taskctl.
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the config directory: %w", err)
+	}
+	return filepath.Join(dir, "taskctl"), nil
+}
+
+// loadClientConfig reads config.yaml; a missing file is an empty config.
+func loadClientConfig() (*clientConfig, error) {
+	cfg := &clientConfig{}
+	if err := readYAMLFile(configFileName, cfg, false); err != nil {
+		return nil, err
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*profile{}
+	}
+	return cfg, nil
+}
+
+// loadCredentials reads credentials.yaml, refusing files that other users
+// can read or write.
+func loadCredentials() (*credentialsFile, error) {
+	creds := &credentialsFile{}
+	if err := readYAMLFile(credentialsFileName, creds, true); err != nil {
+		return nil, err
+	}
+	if creds.Credentials == nil {
+		creds.Credentials = map[string]*credentials{}
+	}
+	return creds, nil
+}
+
+func readYAMLFile(name string, out any, private bool) error {
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if private {
+		if err := checkPrivate(file, path); err != nil {
+			return err
+		}
+	}
+
+	dec := yaml.NewDecoder(file)
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// checkPrivate rejects files accessible by the group or other users. The
+// check is skipped on Windows, where permissions are ACL based.
+func checkPrivate(file *os.File, path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Errorf("%s is accessible by other users (mode %04o); run: chmod 600 %s", path, perm, path)
+	}
+	return nil
+}
+
+func saveClientConfig(cfg *clientConfig) error {
+	return writeYAMLFile(configFileName, cfg, 0o644)
+}
+
+func saveCredentials(creds *credentialsFile) error {
+	return writeYAMLFile(credentialsFileName, creds, 0o600)
+}
+
+// writeYAMLFile replaces the file atomically so a failed write never leaves
+// a truncated config behind.
+func writeYAMLFile(name string, value any, perm os.FileMode) error {
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var data bytes.Buffer
+	enc := yaml.NewEncoder(&data)
+	enc.SetIndent(2)
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := data.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
+
+// applyProfile fills the options not given as flags or environment
+// variables from the selected profile and its credentials.
+func (o *globalOptions) applyProfile(cmd *cobra.Command) error {
+	cfg, err := loadClientConfig()
+	if err != nil {
+		return err
+	}
+
+	name := o.profileName
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	p := &profile{}
+	var creds *credentials
+	if name != "" {
+		var ok bool
+		if p, ok = cfg.Profiles[name]; !ok {
+			return usageErrorf("profile %q does not exist", name)
+		}
+		credsFile, err := loadCredentials()
+		if err != nil {
+			return err
+		}
+		creds = credsFile.Credentials[name]
+	}
+	if creds == nil {
+		creds = &credentials{}
+	}
+
+	flags := cmd.Flags()
+	setString := func(flag string, target *string, env, fromProfile string) {
+		if flags.Changed(flag) {
+			return
+		}
+		if value := os.Getenv(env); env != "" && value != "" {
+			*target = value
+		} else if fromProfile != "" {
+			*target = fromProfile
+		}
+	}
+	setBool := func(flag string, target *bool, fromProfile bool) {
+		if !flags.Changed(flag) && fromProfile {
+			*target = true
+		}
+	}
+
+	setString("addr", &o.addr, "GRPC_SERVER_ADDR", p.Address)
+	setBool("tls", &o.useTLS, p.TLS.Enabled)
+	setString("ca-cert", &o.caFile, "", p.TLS.CACert)
+	setString("cert", &o.certFile, "", p.TLS.Cert)
+	setString("key", &o.keyFile, "", p.TLS.Key)
+	setString("server-name", &o.serverName, "", p.TLS.ServerName)
+	setBool("tls-insecure", &o.insecure, p.TLS.Insecure)
+
+	o.token = firstNonEmpty(os.Getenv("GRPC_AUTH_TOKEN"), creds.Token)
+	o.apiKey = firstNonEmpty(os.Getenv("GRPC_API_KEY"), creds.APIKey)
+	o.defaultUser = p.DefaultUser
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func newProfileCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage connection profiles in ~/.config/taskctl",
+		Long: `Profiles store the address, TLS settings and default user of an
+environment in ~/.config/taskctl/config.yaml. Tokens and API keys go to
+credentials.yaml, which must only be readable by its owner (mode 0600).
+
+Flags and the GRPC_SERVER_ADDR, GRPC_AUTH_TOKEN and GRPC_API_KEY
+environment variables take precedence over the selected profile.`,
+		// Los subcomandos gestionan los archivos, no se conectan
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.output.validate()
+		},
+	}
+
+	cmd.AddCommand(
+		newProfileListCommand(),
+		newProfileShowCommand(),
+		newProfileUseCommand(),
+		newProfileSetCommand(),
+		newProfileDeleteCommand(),
+	)
+	return cmd
+}
+
+func newProfileListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the profiles, marking the current one",
+		Args:  args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadClientConfig()
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(cfg.Profiles))
+			for name := range cfg.Profiles {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+
+			for _, name := range names {
+				marker := " "
+				if name == cfg.CurrentProfile {
+					marker = "*"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s\t%s\n", marker, name, cfg.Profiles[name].Address)
+			}
+			return nil
+		},
+	}
+}
+
+func newProfileShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "show [NAME]",
+		Short:             "Print a profile, the current one by default, with its credentials redacted",
+		ValidArgsFunction: completeProfiles,
+		Args:              args(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadClientConfig()
+			if err != nil {
+				return err
+			}
+
+			name := cfg.CurrentProfile
+			if len(args) == 1 {
+				name = args[0]
+			}
+			p, ok := cfg.Profiles[name]
+			if !ok {
+				return usageErrorf("profile %q does not exist", name)
+			}
+
+			creds, err := loadCredentials()
+			if err != nil {
+				return err
+			}
+			shown := struct {
+				Name        string `yaml:"name"`
+				*profile    `yaml:",inline"`
+				Credentials credentials `yaml:"credentials,omitempty"`
+			}{Name: name, profile: p}
+			if c := creds.Credentials[name]; c != nil {
+				shown.Credentials = credentials{Token: redact(c.Token), APIKey: redact(c.APIKey)}
+			}
+
+			enc := yaml.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent(2)
+			if err := enc.Encode(shown); err != nil {
+				return err
+			}
+			return enc.Close()
+		},
+	}
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "REDACTED"
+}
+
+func newProfileUseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "use NAME",
+		Short:             "Select the profile used by default",
+		ValidArgsFunction: completeProfiles,
+		Args:              args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadClientConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Profiles[args[0]]; !ok {
+				return usageErrorf("profile %q does not exist", args[0])
+			}
+
+			cfg.CurrentProfile = args[0]
+			if err := saveClientConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "using profile %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newProfileSetCommand() *cobra.Command {
+	var tokenStdin, apiKeyStdin bool
+
+	cmd := &cobra.Command{
+		Use:   "set NAME",
+		Short: "Create or update a profile from the connection flags",
+		Example: `  taskctl --addr tasks.example.com:443 --tls profile set prod --user alice
+  taskctl profile set prod --token-stdin < token.jwt`,
+		Args: args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if tokenStdin && apiKeyStdin {
+				return usageErrorf("--token-stdin and --api-key-stdin are mutually exclusive")
+			}
+
+			cfg, err := loadClientConfig()
+			if err != nil {
+				return err
+			}
+			p := cfg.Profiles[name]
+			if p == nil {
+				p = &profile{}
+				cfg.Profiles[name] = p
+			}
+
+			// Solo se guardan los flags indicados explícitamente
+			flags := cmd.Flags()
+			stringFlags := map[string]*string{
+				"addr":        &p.Address,
+				"ca-cert":     &p.TLS.CACert,
+				"cert":        &p.TLS.Cert,
+				"key":         &p.TLS.Key,
+				"server-name": &p.TLS.ServerName,
+				"user":        &p.DefaultUser,
+			}
+			for flag, target := range stringFlags {
+				if flags.Changed(flag) {
+					*target, _ = flags.GetString(flag)
+				}
+			}
+			for flag, target := range map[string]*bool{"tls": &p.TLS.Enabled, "tls-insecure": &p.TLS.Insecure} {
+				if flags.Changed(flag) {
+					*target, _ = flags.GetBool(flag)
+				}
+			}
+			if cfg.CurrentProfile == "" {
+				cfg.CurrentProfile = name
+			}
+
+			if tokenStdin || apiKeyStdin {
+				secret, err := readSecret(cmd.InOrStdin())
+				if err != nil {
+					return err
+				}
+				creds, err := loadCredentials()
+				if err != nil {
+					return err
+				}
+				c := creds.Credentials[name]
+				if c == nil {
+					c = &credentials{}
+					creds.Credentials[name] = c
+				}
+				if tokenStdin {
+					c.Token = secret
+				} else {
+					c.APIKey = secret
+				}
+				if err := saveCredentials(creds); err != nil {
+					return err
+				}
+			}
+
+			if err := saveClientConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "saved profile %s\n", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("user", "", "default user for create and list")
+	cmd.Flags().BoolVar(&tokenStdin, "token-stdin", false, "read the JWT from stdin and store it in credentials.yaml")
+	cmd.Flags().BoolVar(&apiKeyStdin, "api-key-stdin", false, "read the API key from stdin and store it in credentials.yaml")
+	return cmd
+}
+
+// readSecret reads a token from stdin, so it never appears in the shell
+// history or the process list.
+func readSecret(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", usageErrorf("no secret on stdin")
+	}
+	return secret, nil
+}
+
+func newProfileDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete NAME",
+		Short:             "Delete a profile and its credentials",
+		ValidArgsFunction: completeProfiles,
+		Args:              args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cfg, err := loadClientConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Profiles[name]; !ok {
+				return usageErrorf("profile %q does not exist", name)
+			}
+
+			creds, err := loadCredentials()
+			if err != nil {
+				return err
+			}
+			if _, ok := creds.Credentials[name]; ok {
+				delete(creds.Credentials, name)
+				if err := saveCredentials(creds); err != nil {
+					return err
+				}
+			}
+
+			delete(cfg.Profiles, name)
+			if cfg.CurrentProfile == name {
+				cfg.CurrentProfile = ""
+			}
+			if err := saveClientConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted profile %s\n", name)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tlsconfig"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskclient"
+	"github.com/spf13/cobra"
+)
+
+// globalOptions are the connection flags shared by every command, completed
+// from the selected profile.
+type globalOptions struct {
+	profileName string
+	addr        string
+	useTLS      bool
+	caFile      string
+	certFile    string
+	keyFile     string
+	serverName  string
+	insecure    bool
+	timeout     time.Duration
+	output      printer
+
+	token       string
+	apiKey      string
+	defaultUser string
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &globalOptions{}
+
+	root := &cobra.Command{
+		Use:           "taskctl",
+		Short:         "Command-line client for the task manager gRPC API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError{err}
+	})
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.profileName, "profile", os.Getenv("TASKCTL_PROFILE"), "profile from ~/.config/taskctl/config.yaml (env TASKCTL_PROFILE)")
+	flags.StringVar(&opts.addr, "addr", taskclient.DefaultAddress, "gRPC server address (env GRPC_SERVER_ADDR)")
+	flags.BoolVar(&opts.useTLS, "tls", false, "connect using TLS")
+	flags.StringVar(&opts.caFile, "ca-cert", "", "CA bundle to verify the server certificate")
+	flags.StringVar(&opts.certFile, "cert", "", "client certificate for mTLS")
+	flags.StringVar(&opts.keyFile, "key", "", "private key of the client certificate")
+	flags.StringVar(&opts.serverName, "server-name", "", "expected name in the server certificate")
+	flags.BoolVar(&opts.insecure, "tls-insecure", false, "skip server certificate verification (development only)")
+	flags.DurationVar(&opts.timeout, "timeout", 5*time.Second, "timeout of each RPC")
+	flags.StringVarP(&opts.output.format, "output", "o", outputText, "output format: "+strings.Join(outputFormats, ", "))
+	flags.StringSliceVar(&opts.output.columns, "columns", nil, "columns for table and csv output: "+strings.Join(taskColumns, ", "))
+	flags.StringVar(&opts.output.template, "template", "", "Go text/template rendered per task with --output template, e.g. '{{.id}} {{.title}}'")
+
+	root.RegisterFlagCompletionFunc("profile", completeProfiles)
+	root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(outputFormats, cobra.ShellCompDirectiveNoFileComp))
+	root.RegisterFlagCompletionFunc("columns", cobra.FixedCompletions(taskColumns, cobra.ShellCompDirectiveNoFileComp))
+
+	root.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		if err := opts.output.validate(); err != nil {
+			return err
+		}
+		return opts.applyProfile(cmd)
+	}
+
+	// complete y delete también están en la raíz para las operaciones con --where
+	root.AddCommand(newTasksCommand(opts), newCompleteCommand(opts), newDeleteCommand(opts), newExportCommand(opts), newImportCommand(opts), newTodoCommand(opts), newCalendarCommand(opts), newProfileCommand(opts))
+	return root
+}
+
+// dial connects to the server with the credentials from the environment or
+// the profile.
+func (o *globalOptions) dial() (*TaskClient, error) {
+	options := []taskclient.Option{
+		taskclient.WithAddress(o.addr),
+		taskclient.WithToken(o.token),
+		taskclient.WithAPIKey(o.apiKey),
+		taskclient.WithTimeout(o.timeout),
+	}
+	if o.useTLS || o.caFile != "" || o.certFile != "" {
+		tlsCfg, err := tlsconfig.ClientConfig(tlsconfig.ClientOptions{
+			CAFile:     o.caFile,
+			CertFile:   o.certFile,
+			KeyFile:    o.keyFile,
+			ServerName: o.serverName,
+			Insecure:   o.insecure,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS: %w", err)
+		}
+		options = append(options, taskclient.WithTLS(tlsCfg))
+	}
+
+	return NewTaskClient(options...)
+}
+
+// rpcContext bounds a single RPC by the --timeout flag.
+func (o *globalOptions) rpcContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, o.timeout)
+}
@@ -0,0 +1,456 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskclient"
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTasksCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tasks",
+		Short: "Create, inspect and manage tasks",
+	}
+
+	cmd.AddCommand(
+		newCreateCommand(opts),
+		newGetCommand(opts),
+		newUpdateCommand(opts),
+		newDeleteCommand(opts),
+		newCompleteCommand(opts),
+		newListCommand(opts),
+		newShellCommand(opts),
+		newTUICommand(opts),
+	)
+	return cmd
+}
+
+// withClient connects to the server for the duration of fn.
+func withClient(opts *globalOptions, fn func(client *TaskClient) error) error {
+	client, err := opts.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return fn(client)
+}
+
+// args wraps a cobra argument validator so its errors exit as usage errors.
+func args(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError{err}
+		}
+		return nil
+	}
+}
+
+func newCreateCommand(opts *globalOptions) *cobra.Command {
+	var (
+		userID      string
+		title       string
+		description string
+		completed   bool
+		fromFile    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a task",
+		Long: `Create a task from flags, or several from a file of JSON lines
+(one CreateTaskRequest per line) with --from-file, where "-" reads stdin.`,
+		Example: `  taskctl tasks create --title "Write docs" --description "API reference"
+  cat tasks.ndjson | taskctl tasks create --from-file -`,
+		Args: args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if fromFile != "" {
+				return withClient(opts, func(client *TaskClient) error {
+					var created []*taskpb.Task
+					err := forEachJSONLine(cmd, opts, fromFile, func() *taskpb.CreateTaskRequest { return &taskpb.CreateTaskRequest{} },
+						func(ctx context.Context, req *taskpb.CreateTaskRequest) error {
+							resp, err := client.client.CreateTask(ctx, req)
+							if err != nil {
+								return err
+							}
+							created = append(created, resp.Task)
+							return nil
+						})
+					return printThenFail(cmd, opts, created, err)
+				})
+			}
+
+			if title == "" {
+				return usageErrorf("--title is required")
+			}
+			req := &taskpb.CreateTaskRequest{
+				UserId:    firstNonEmpty(userID, opts.defaultUser),
+				Title:     title,
+				Completed: &completed,
+			}
+			if cmd.Flags().Changed("description") {
+				req.Description = &description
+			}
+
+			return withClient(opts, func(client *TaskClient) error {
+				ctx, cancel := opts.rpcContext(cmd.Context())
+				defer cancel()
+
+				resp, err := client.client.CreateTask(ctx, req)
+				if err != nil {
+					return err
+				}
+				return opts.output.printTask(cmd.OutOrStdout(), resp.Task)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the task (defaults to the profile user, then the authenticated user)")
+	cmd.Flags().StringVar(&title, "title", "", "title of the task")
+	cmd.Flags().StringVar(&description, "description", "", "description of the task")
+	cmd.Flags().BoolVar(&completed, "completed", false, "create the task as completed")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", `create tasks from a JSON lines file ("-" for stdin)`)
+	cmd.MarkFlagsMutuallyExclusive("from-file", "title")
+	return cmd
+}
+
+func newGetCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID... | -",
+		Short: "Show one or more tasks",
+		Long: `Show one or more tasks. IDs can be shortened to a unique prefix of at
+least 4 characters, e.g. "3f2c".`,
+		Args:              args(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: completeTaskIDs(opts),
+		RunE: func(cmd *cobra.Command, ids []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				var tasks []*taskpb.Task
+				err := forEachID(cmd, opts, client, ids, func(ctx context.Context, id string) error {
+					resp, err := client.client.GetTask(ctx, &taskpb.GetTaskRequest{Id: id})
+					if err != nil {
+						return err
+					}
+					tasks = append(tasks, resp.Task)
+					return nil
+				})
+				if len(ids) == 1 && len(tasks) == 1 {
+					return opts.output.printTask(cmd.OutOrStdout(), tasks[0])
+				}
+				return printThenFail(cmd, opts, tasks, err)
+			})
+		},
+	}
+}
+
+func newUpdateCommand(opts *globalOptions) *cobra.Command {
+	var (
+		title       string
+		description string
+		completed   bool
+		fromFile    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update the fields of a task",
+		Long: `Update the fields given as flags, or apply a file of JSON lines
+(one UpdateTaskRequest per line) with --from-file, where "-" reads stdin.`,
+		Example:           `  taskctl tasks update 3f2c --title "New title" --completed=false`,
+		Args:              args(cobra.MaximumNArgs(1)),
+		ValidArgsFunction: completeFirstTaskID(opts),
+		RunE: func(cmd *cobra.Command, ids []string) error {
+			if fromFile != "" {
+				if len(ids) > 0 {
+					return usageErrorf("an ID cannot be combined with --from-file")
+				}
+				return withClient(opts, func(client *TaskClient) error {
+					var updated []*taskpb.Task
+					err := forEachJSONLine(cmd, opts, fromFile, func() *taskpb.UpdateTaskRequest { return &taskpb.UpdateTaskRequest{} },
+						func(ctx context.Context, req *taskpb.UpdateTaskRequest) error {
+							resp, err := client.client.UpdateTask(ctx, req)
+							if err != nil {
+								return err
+							}
+							updated = append(updated, resp.Task)
+							return nil
+						})
+					return printThenFail(cmd, opts, updated, err)
+				})
+			}
+
+			if len(ids) != 1 {
+				return usageErrorf("requires the ID of the task")
+			}
+			req := &taskpb.UpdateTaskRequest{}
+			if cmd.Flags().Changed("title") {
+				req.Title = &title
+			}
+			if cmd.Flags().Changed("description") {
+				req.Description = &description
+			}
+			if cmd.Flags().Changed("completed") {
+				req.Completed = &completed
+			}
+			if req.Title == nil && req.Description == nil && req.Completed == nil {
+				return usageErrorf("nothing to update: set --title, --description or --completed")
+			}
+
+			return withClient(opts, func(client *TaskClient) error {
+				ctx, cancel := opts.rpcContext(cmd.Context())
+				defer cancel()
+
+				id, err := newIDResolver(client, opts).resolve(ctx, ids[0])
+				if err != nil {
+					return err
+				}
+				req.Id = id
+
+				resp, err := client.client.UpdateTask(ctx, req)
+				if err != nil {
+					return err
+				}
+				return opts.output.printTask(cmd.OutOrStdout(), resp.Task)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", "new title")
+	cmd.Flags().StringVar(&description, "description", "", "new description")
+	cmd.Flags().BoolVar(&completed, "completed", false, "mark the task as completed or pending")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", `apply updates from a JSON lines file ("-" for stdin)`)
+	return cmd
+}
+
+func newDeleteCommand(opts *globalOptions) *cobra.Command {
+	var matching matchingFlags
+
+	cmd := &cobra.Command{
+		Use:               "delete ID... | - | --where QUERY",
+		Short:             "Delete one or more tasks, or the tasks matching a query",
+		Long:              "Delete the given tasks, or the tasks matching --where.\n\n" + whereHelp,
+		Example:           `  taskctl delete --where 'user=alice tag=sprint-12 completed=true'`,
+		Args:              args(idsOrWhere),
+		ValidArgsFunction: completeTaskIDs(opts),
+		RunE: func(cmd *cobra.Command, ids []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				if matching.where != "" {
+					return runMatching(cmd, opts, client, matching, matchingOperation{
+						verb: "delete",
+						done: "deleted",
+						call: func(ctx context.Context, filter *taskpb.TaskFilter, dryRun bool, expected []string) (grpc.ServerStreamingClient[taskpb.TasksMatchingProgress], error) {
+							return client.client.DeleteTasksMatching(ctx, &taskpb.DeleteTasksMatchingRequest{Filter: filter, DryRun: dryRun, ExpectedIds: expected})
+						},
+					})
+				}
+
+				return forEachID(cmd, opts, client, ids, func(ctx context.Context, id string) error {
+					if _, err := client.client.DeleteTask(ctx, &taskpb.DeleteTaskRequest{Id: id}); err != nil {
+						return err
+					}
+					if opts.output.format == outputText {
+						fmt.Fprintf(cmd.OutOrStdout(), "deleted %s\n", id)
+					}
+					return nil
+				})
+			})
+		},
+	}
+
+	matching.register(cmd)
+	return cmd
+}
+
+func newCompleteCommand(opts *globalOptions) *cobra.Command {
+	var matching matchingFlags
+
+	cmd := &cobra.Command{
+		Use:               "complete ID... | - | --where QUERY",
+		Short:             "Mark one or more tasks, or the tasks matching a query, as completed",
+		Long:              "Mark as completed the given tasks, or the open tasks matching --where.\n\n" + whereHelp,
+		Example:           `  taskctl complete --where 'user=alice tag=sprint-12'`,
+		Args:              args(idsOrWhere),
+		ValidArgsFunction: completeTaskIDs(opts),
+		RunE: func(cmd *cobra.Command, ids []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				if matching.where != "" {
+					return runMatching(cmd, opts, client, matching, matchingOperation{
+						verb: "complete",
+						done: "completed",
+						call: func(ctx context.Context, filter *taskpb.TaskFilter, dryRun bool, expected []string) (grpc.ServerStreamingClient[taskpb.TasksMatchingProgress], error) {
+							return client.client.CompleteTasksMatching(ctx, &taskpb.CompleteTasksMatchingRequest{Filter: filter, DryRun: dryRun, ExpectedIds: expected})
+						},
+					})
+				}
+
+				var completed []*taskpb.Task
+				err := forEachID(cmd, opts, client, ids, func(ctx context.Context, id string) error {
+					resp, err := client.client.MarkTaskComplete(ctx, &taskpb.MarkTaskCompleteRequest{Id: id})
+					if err != nil {
+						return err
+					}
+					completed = append(completed, resp.Task)
+					return nil
+				})
+				return printThenFail(cmd, opts, completed, err)
+			})
+		},
+	}
+
+	matching.register(cmd)
+	return cmd
+}
+
+func newListCommand(opts *globalOptions) *cobra.Command {
+	var (
+		userID        string
+		all           bool
+		includeShared bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the tasks of a user, or every task with --all",
+		Args:  args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				// Sin deadline global: el SDK aplica --timeout a cada página
+				ctx := cmd.Context()
+
+				var listOpts []taskclient.ListOption
+				if includeShared {
+					listOpts = append(listOpts, taskclient.IncludeShared())
+				}
+
+				pages := client.sdk.ListTasksByUser(ctx, firstNonEmpty(userID, opts.defaultUser), listOpts...)
+				if all {
+					pages = client.sdk.ListAllTasks(ctx)
+				}
+				tasks, err := taskclient.Collect(pages)
+				if err != nil {
+					return err
+				}
+
+				return opts.output.printTasks(cmd.OutOrStdout(), tasks)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the tasks (defaults to the profile user, then the authenticated user)")
+	cmd.Flags().BoolVar(&all, "all", false, "list every task (admin only)")
+	cmd.Flags().BoolVar(&includeShared, "include-shared", false, "include tasks shared with the user")
+	cmd.MarkFlagsMutuallyExclusive("all", "user")
+	return cmd
+}
+
+func newShellCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "shell",
+		Short: "Start the interactive menu",
+		Args:  args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withClient(opts, func(client *TaskClient) error {
+				runShell(client, opts.addr)
+				return nil
+			})
+		},
+	}
+}
+
+// printThenFail prints the tasks of a bulk operation that succeeded before
+// returning the error of the ones that failed.
+func printThenFail(cmd *cobra.Command, opts *globalOptions, tasks []*taskpb.Task, err error) error {
+	if len(tasks) > 0 {
+		if printErr := opts.output.printTasks(cmd.OutOrStdout(), tasks); printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
+
+// forEachID runs fn for every ID given as argument, or read one per line
+// from stdin when the only argument is "-", expanding short prefixes.
+// Failures are reported and the remaining IDs are still processed.
+func forEachID(cmd *cobra.Command, opts *globalOptions, client *TaskClient, ids []string, fn func(ctx context.Context, id string) error) error {
+	if len(ids) == 1 && ids[0] == "-" {
+		var err error
+		if ids, err = readLines(cmd.InOrStdin()); err != nil {
+			return err
+		}
+	}
+
+	resolver := newIDResolver(client, opts)
+	result := &bulkError{total: len(ids)}
+	for _, id := range ids {
+		ctx, cancel := opts.rpcContext(cmd.Context())
+		fullID, err := resolver.resolve(ctx, id)
+		if err == nil {
+			err = fn(ctx, fullID)
+		}
+		cancel()
+		if err != nil {
+			result.record(cmd, id, err)
+		}
+	}
+	return result.err()
+}
+
+// forEachJSONLine decodes every line of path ("-" for stdin) into a new
+// request and runs fn with it.
+func forEachJSONLine[T proto.Message](cmd *cobra.Command, opts *globalOptions, path string, newRequest func() T, fn func(ctx context.Context, req T) error) error {
+	in := cmd.InOrStdin()
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	lines, err := readLines(in)
+	if err != nil {
+		return err
+	}
+
+	result := &bulkError{total: len(lines)}
+	for i, line := range lines {
+		req := newRequest()
+		err := protojson.Unmarshal([]byte(line), req)
+		if err != nil {
+			err = usageErrorf("invalid JSON: %v", err)
+		} else {
+			ctx, cancel := opts.rpcContext(cmd.Context())
+			err = fn(ctx, req)
+			cancel()
+		}
+		if err != nil {
+			result.record(cmd, fmt.Sprintf("line %d", i+1), err)
+		}
+	}
+	return result.err()
+}
+
+// readLines returns the non-empty lines of r, skipping # comments.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,519 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskclient"
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/spf13/cobra"
+)
+
+// Formats of the todo commands.
+const (
+	formatTodoTxt  = "todotxt"
+	formatMarkdown = "markdown"
+)
+
+// todoFormat returns the explicit --format, or guesses it from the file
+// extension. Anything but .md and .markdown is read as todo.txt.
+func todoFormat(format, path string) (string, error) {
+	switch format {
+	case formatTodoTxt, formatMarkdown:
+		return format, nil
+	case "":
+	default:
+		return "", usageErrorf("invalid --format %q (valid: todotxt, markdown)", format)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return formatMarkdown, nil
+	default:
+		return formatTodoTxt, nil
+	}
+}
+
+// todoFile is a parsed todo.txt or Markdown file. The lines that are not
+// tasks, such as the headings of a Markdown file, are kept as they are so
+// sync can rewrite the file without losing them.
+type todoFile struct {
+	format string
+	lines  []todoLine
+}
+
+// todoLine is either a raw line or a task, with its indentation in
+// Markdown.
+type todoLine struct {
+	raw    string
+	item   *todoItem
+	indent string
+}
+
+func parseTodoFile(r io.Reader, format string) (*todoFile, error) {
+	file := &todoFile{format: format}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var (
+		current     *todoItem // tarea Markdown que recibe la descripción
+		indent      string
+		description []string
+	)
+	flush := func() {
+		if current != nil {
+			// Las líneas en blanco finales separan tareas, no son descripción
+			for len(description) > 0 && description[len(description)-1] == "" {
+				file.lines = append(file.lines, todoLine{raw: ""})
+				description = description[:len(description)-1]
+			}
+			current.Description = strings.Join(description, "\n")
+		}
+		current, description = nil, nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if format == formatTodoTxt {
+			if strings.TrimSpace(line) == "" {
+				file.lines = append(file.lines, todoLine{raw: line})
+				continue
+			}
+			file.lines = append(file.lines, todoLine{item: parseTodoLine(line)})
+			continue
+		}
+
+		if current != nil {
+			prefix := indent + "  "
+			if line == "" {
+				description = append(description, "")
+				continue
+			}
+			if rest, ok := strings.CutPrefix(line, prefix); ok {
+				if _, _, isItem := parseMarkdownItem(line); !isItem {
+					description = append(description, rest)
+					continue
+				}
+			}
+		}
+
+		flush()
+		if item, width, ok := parseMarkdownItem(line); ok {
+			current, indent = item, line[:width]
+			file.lines = append(file.lines, todoLine{item: item, indent: indent})
+			continue
+		}
+		file.lines = append(file.lines, todoLine{raw: line})
+	}
+	flush()
+	return file, scanner.Err()
+}
+
+// items returns the tasks of the file.
+func (f *todoFile) items() []*todoItem {
+	var items []*todoItem
+	for _, line := range f.lines {
+		if line.item != nil {
+			items = append(items, line.item)
+		}
+	}
+	return items
+}
+
+func (f *todoFile) add(item *todoItem) {
+	f.lines = append(f.lines, todoLine{item: item})
+}
+
+// remove drops the line of the item.
+func (f *todoFile) remove(item *todoItem) {
+	f.lines = slices.DeleteFunc(f.lines, func(line todoLine) bool { return line.item == item })
+}
+
+func (f *todoFile) write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, line := range f.lines {
+		switch {
+		case line.item == nil:
+			fmt.Fprintln(bw, line.raw)
+		case f.format == formatMarkdown:
+			fmt.Fprintln(bw, line.item.markdown(line.indent))
+		default:
+			fmt.Fprintln(bw, line.item.todoTxt())
+		}
+	}
+	return bw.Flush()
+}
+
+// save replaces the file atomically, keeping its permissions.
+func (f *todoFile) save(path string) error {
+	var data bytes.Buffer
+	if err := f.write(&data); err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := data.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func newTodoCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "todo",
+		Short: "Convert and sync todo.txt files and Markdown checklists",
+		Long: `Convert tasks from and to todo.txt files and Markdown checklists
+("- [ ] text", "- [x] text"), or keep one of those files in sync with the
+server.
+
+Both formats follow the todo.txt rules: "x" (or a checked box) marks a
+completed task, "(A)" its priority, then the completion and creation dates
+and the text with its +projects, @contexts and key:value tags. Tasks have
+no priority nor dates of their own, so they are kept at the end of the
+title as the tags pri:, created: and done:, and restored on export.
+
+The task ID is written as an id: tag in todo.txt and as an HTML comment in
+Markdown. The description becomes an escaped note: tag in todo.txt and the
+lines indented under the item in Markdown.`,
+	}
+
+	cmd.PersistentFlags().String("format", "", "todotxt or markdown (defaults to the file extension, else todotxt)")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{formatTodoTxt, formatMarkdown}, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.AddCommand(
+		newTodoImportCommand(opts),
+		newTodoExportCommand(opts),
+		newTodoSyncCommand(opts),
+	)
+	return cmd
+}
+
+func newTodoImportCommand(opts *globalOptions) *cobra.Command {
+	var userID string
+
+	cmd := &cobra.Command{
+		Use:   "import FILE | -",
+		Short: "Create a task for every item of a todo.txt or Markdown file",
+		Long: `Create a task for every item of the file. The IDs in the file are
+ignored; use sync to match the items with existing tasks.`,
+		Example: `  taskctl todo import todo.txt
+  taskctl todo import --format markdown - < NOTES.md`,
+		Args: args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, files []string) error {
+			format, err := todoFormat(cmd.Flag("format").Value.String(), files[0])
+			if err != nil {
+				return err
+			}
+
+			in := cmd.InOrStdin()
+			if files[0] != "-" {
+				file, err := os.Open(files[0])
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				in = file
+			}
+			file, err := parseTodoFile(in, format)
+			if err != nil {
+				return err
+			}
+
+			return withClient(opts, func(client *TaskClient) error {
+				items := file.items()
+				result := &bulkError{total: len(items)}
+				var created []*taskpb.Task
+				for _, item := range items {
+					ctx, cancel := opts.rpcContext(cmd.Context())
+					resp, err := client.client.CreateTask(ctx, item.createRequest(firstNonEmpty(userID, opts.defaultUser)))
+					cancel()
+					if err != nil {
+						result.record(cmd, item.Text, err)
+						continue
+					}
+					created = append(created, resp.Task)
+				}
+				return printThenFail(cmd, opts, created, result.err())
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the tasks (defaults to the profile user, then the authenticated user)")
+	return cmd
+}
+
+func newTodoExportCommand(opts *globalOptions) *cobra.Command {
+	var (
+		userID        string
+		includeShared bool
+		fileName      string
+		projects      []string
+		contexts      []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the tasks of a user as todo.txt or a Markdown checklist",
+		Example: `  taskctl todo export --file todo.txt
+  taskctl todo export --format markdown --project release`,
+		Args: args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := todoFormat(cmd.Flag("format").Value.String(), fileName)
+			if err != nil {
+				return err
+			}
+
+			return withClient(opts, func(client *TaskClient) error {
+				var listOpts []taskclient.ListOption
+				if includeShared {
+					listOpts = append(listOpts, taskclient.IncludeShared())
+				}
+				tasks, err := taskclient.Collect(client.sdk.ListTasksByUser(cmd.Context(), firstNonEmpty(userID, opts.defaultUser), listOpts...))
+				if err != nil {
+					return err
+				}
+
+				file := &todoFile{format: format}
+				for _, task := range tasks {
+					item := todoItemFromTask(task)
+					if hasAllTags(item.tags("+"), projects) && hasAllTags(item.tags("@"), contexts) {
+						file.add(item)
+					}
+				}
+
+				if fileName == "" || fileName == "-" {
+					return file.write(cmd.OutOrStdout())
+				}
+				return file.save(fileName)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the tasks (defaults to the profile user, then the authenticated user)")
+	cmd.Flags().BoolVar(&includeShared, "include-shared", false, "include tasks shared with the user")
+	cmd.Flags().StringVarP(&fileName, "file", "f", "", "write to a file instead of stdout")
+	cmd.Flags().StringSliceVar(&projects, "project", nil, "only tasks with these +projects")
+	cmd.Flags().StringSliceVar(&contexts, "context", nil, "only tasks with these @contexts")
+	return cmd
+}
+
+func hasAllTags(tags, wanted []string) bool {
+	for _, tag := range wanted {
+		if !slices.Contains(tags, strings.TrimLeft(tag, "+@")) {
+			return false
+		}
+	}
+	return true
+}
+
+// todoSync counts the changes made by sync.
+type todoSync struct {
+	created, updated, pulled, added, removed, missing int
+}
+
+func newTodoSyncCommand(opts *globalOptions) *cobra.Command {
+	var (
+		userID string
+		dryRun bool
+		prune  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync FILE",
+		Short: "Sync a todo.txt or Markdown file with the tasks of a user",
+		Long: `Sync a todo.txt file or Markdown checklist with the tasks of a user:
+
+  - items without an ID are created, and their ID written to the file;
+  - items that differ from their task are pushed to the server, unless the
+    task changed after the file was last written, in which case the task
+    wins and the item is rewritten;
+  - tasks missing from the file are appended to it.
+
+Items whose task no longer exists are kept, unless --prune removes them;
+deleting an item from the file never deletes its task. The rest of the
+file, such as Markdown headings, is kept as is.`,
+		Example: `  taskctl todo sync ~/todo.txt
+  taskctl todo sync --dry-run TODO.md`,
+		Args: args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, files []string) error {
+			path := files[0]
+			format, err := todoFormat(cmd.Flag("format").Value.String(), path)
+			if err != nil {
+				return err
+			}
+
+			file := &todoFile{format: format}
+			var modified time.Time
+			if f, err := os.Open(path); err == nil {
+				info, statErr := f.Stat()
+				if statErr == nil {
+					modified = info.ModTime()
+					file, err = parseTodoFile(f, format)
+				}
+				f.Close()
+				if err = errors.Join(statErr, err); err != nil {
+					return err
+				}
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			return withClient(opts, func(client *TaskClient) error {
+				owner := firstNonEmpty(userID, opts.defaultUser)
+				tasks, err := taskclient.Collect(client.sdk.ListTasksByUser(cmd.Context(), owner))
+				if err != nil {
+					return err
+				}
+
+				stats, err := syncTodoFile(cmd, opts, client, file, tasks, owner, modified, dryRun, prune)
+				if !dryRun && stats != (todoSync{}) {
+					// También tras un fallo parcial, para no perder los IDs creados
+					if saveErr := file.save(path); saveErr != nil {
+						return errors.Join(err, saveErr)
+					}
+				}
+
+				prefix := ""
+				if dryRun {
+					prefix = "dry run: "
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "%screated %d, updated %d, pulled %d, added %d, removed %d\n",
+					prefix, stats.created, stats.updated, stats.pulled, stats.added, stats.removed)
+				if stats.missing > 0 {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%d items refer to tasks that no longer exist; use --prune to remove them\n", stats.missing)
+				}
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the tasks (defaults to the profile user, then the authenticated user)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the changes without applying them")
+	cmd.Flags().BoolVar(&prune, "prune", false, "remove the items whose task no longer exists")
+	return cmd
+}
+
+// syncTodoFile applies the changes of the file to the server and of the
+// server to the file, printing one line per change. Failed RPCs are
+// reported and the rest of the items are still synced.
+func syncTodoFile(cmd *cobra.Command, opts *globalOptions, client *TaskClient, file *todoFile, tasks []*taskpb.Task, owner string, modified time.Time, dryRun, prune bool) (todoSync, error) {
+	var stats todoSync
+	out := cmd.OutOrStdout()
+	byID := make(map[string]*taskpb.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.Id] = task
+	}
+
+	call := func(fn func(ctx context.Context) error) error {
+		if dryRun {
+			return nil
+		}
+		ctx, cancel := opts.rpcContext(cmd.Context())
+		defer cancel()
+		return fn(ctx)
+	}
+
+	items := file.items()
+	result := &bulkError{total: len(items)}
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.ID == "" {
+			err := call(func(ctx context.Context) error {
+				resp, err := client.client.CreateTask(ctx, item.createRequest(owner))
+				if err == nil {
+					item.ID = resp.Task.Id
+				}
+				return err
+			})
+			if err != nil {
+				result.record(cmd, item.Text, err)
+				continue
+			}
+			stats.created++
+			fmt.Fprintf(out, "created  %s\n", item.Text)
+			continue
+		}
+
+		task, ok := byID[item.ID]
+		if !ok || seen[item.ID] {
+			if prune {
+				file.remove(item)
+				stats.removed++
+				fmt.Fprintf(out, "removed  %s\n", item.Text)
+			} else {
+				stats.missing++
+			}
+			continue
+		}
+		seen[item.ID] = true
+
+		remote := todoItemFromTask(task)
+		if item.equal(remote) {
+			continue
+		}
+		if task.UpdatedAt.AsTime().After(modified) {
+			*item = *remote
+			stats.pulled++
+			fmt.Fprintf(out, "pulled   %s\n", item.Text)
+			continue
+		}
+
+		title, description := item.title(), item.Description
+		err := call(func(ctx context.Context) error {
+			_, err := client.client.UpdateTask(ctx, &taskpb.UpdateTaskRequest{
+				Id:          item.ID,
+				Title:       &title,
+				Description: &description,
+				Completed:   &item.Completed,
+			})
+			return err
+		})
+		if err != nil {
+			result.record(cmd, item.Text, err)
+			continue
+		}
+		stats.updated++
+		fmt.Fprintf(out, "updated  %s\n", item.Text)
+	}
+
+	for _, task := range tasks {
+		if seen[task.Id] {
+			continue
+		}
+		item := todoItemFromTask(task)
+		file.add(item)
+		stats.added++
+		fmt.Fprintf(out, "added    %s\n", item.Text)
+	}
+
+	return stats, result.err()
+}
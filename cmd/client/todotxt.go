@@ -0,0 +1,249 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/gofrs/uuid"
+)
+
+// todoDateLayout is the date format of todo.txt.
+const todoDateLayout = "2006-01-02"
+
+// todoItem is a task in the todo.txt model (https://github.com/todotxt/todo.txt),
+// shared by the todo.txt and Markdown checklist formats.
+//
+// Tasks have no priority nor dates of their own, so they travel in the title
+// as the key:value tags pri:, created: and done:, appended after the text.
+// Words of the text that would be read back as todo.txt syntax, such as a
+// leading "x", "(A)" or date, are escaped with a backslash (see escapeText).
+// That keeps the conversion lossless in both directions.
+type todoItem struct {
+	ID          string
+	Completed   bool
+	Priority    string // "A" a "Z", solo en tareas abiertas
+	CompletedOn string // YYYY-MM-DD
+	CreatedOn   string // YYYY-MM-DD
+	Text        string // incluye +proyectos, @contextos y etiquetas key:value
+	Description string
+}
+
+// parseTodoLine parses a line of a todo.txt file.
+func parseTodoLine(line string) *todoItem {
+	item := &todoItem{}
+	if rest, ok := strings.CutPrefix(line, "x "); ok {
+		item.Completed = true
+		line = rest
+	}
+	item.parseBody(line, true)
+	return item
+}
+
+// parseBody parses what follows the completion mark: the priority, the
+// dates and the text. With inline, the id: and note: tags of the text are
+// taken as the ID and the description.
+func (item *todoItem) parseBody(body string, inline bool) {
+	body = strings.TrimSpace(body)
+	if !item.Completed && isTodoPriority(body) {
+		item.Priority, body = body[1:2], strings.TrimSpace(body[3:])
+	}
+
+	first, body := cutTodoDate(body)
+	if item.Completed {
+		// "x 2024-05-02 2024-05-01 texto": la primera fecha es la de completado
+		item.CompletedOn = first
+		if first != "" {
+			item.CreatedOn, body = cutTodoDate(body)
+		}
+	} else {
+		item.CreatedOn = first
+	}
+
+	var text []string
+	for _, token := range strings.Fields(body) {
+		key, value, _ := strings.Cut(token, ":")
+		switch {
+		case strings.HasPrefix(token, `\`):
+			text = append(text, token[1:])
+		case !inline:
+			text = append(text, token)
+		case key == "id" && item.ID == "" && isUUID(value):
+			item.ID = value
+		case key == "note" && item.Description == "":
+			description, err := url.PathUnescape(value)
+			if err != nil {
+				text = append(text, token)
+				continue
+			}
+			item.Description = description
+		default:
+			text = append(text, token)
+		}
+	}
+	item.Text = strings.Join(text, " ")
+}
+
+// todoTxt formats the item as a todo.txt line. The description is escaped
+// into a note: tag, since todo.txt tasks take a single line.
+func (item *todoItem) todoTxt() string {
+	parts := []string{item.body(true)}
+	if item.Completed {
+		parts = append([]string{"x"}, parts...)
+	}
+	if item.ID != "" {
+		parts = append(parts, "id:"+item.ID)
+	}
+	if item.Description != "" {
+		parts = append(parts, "note:"+url.PathEscape(item.Description))
+	}
+	return joinNonEmpty(parts)
+}
+
+// body formats the priority, the dates and the text. With inline, the text
+// is escaped for a todo.txt line, which also holds the id: and note: tags.
+func (item *todoItem) body(inline bool) string {
+	var parts []string
+	if item.Priority != "" && !item.Completed {
+		parts = append(parts, "("+item.Priority+")")
+	}
+	if item.Completed && item.CompletedOn != "" {
+		parts = append(parts, item.CompletedOn)
+	}
+	// todo.txt solo admite la fecha de creación de una tarea completada
+	// si también lleva la de completado
+	if item.CreatedOn != "" && (!item.Completed || item.CompletedOn != "") {
+		parts = append(parts, item.CreatedOn)
+	}
+	parts = append(parts, escapeText(item.Text, inline))
+	return joinNonEmpty(parts)
+}
+
+// escapeText prefixes with a backslash the words of text that parseBody
+// would not read back as text: a first word that looks like a completion
+// mark, a priority or a date, the id: and note: tags when inline, and the
+// words that already start with a backslash. parseBody drops the first
+// backslash of every word.
+func escapeText(text string, inline bool) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		escape := strings.HasPrefix(word, `\`) ||
+			inline && (strings.HasPrefix(word, "id:") || strings.HasPrefix(word, "note:")) ||
+			i == 0 && (inline && word == "x" || isTodoPriority(word+" ") || isTodoDate(word))
+		if escape {
+			words[i] = `\` + word
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// title returns the task title: the text followed by the tags that hold
+// the priority and the dates.
+func (item *todoItem) title() string {
+	parts := []string{item.Text}
+	if item.Priority != "" {
+		parts = append(parts, "pri:"+item.Priority)
+	}
+	if item.CreatedOn != "" {
+		parts = append(parts, "created:"+item.CreatedOn)
+	}
+	if item.CompletedOn != "" {
+		parts = append(parts, "done:"+item.CompletedOn)
+	}
+	return joinNonEmpty(parts)
+}
+
+// createRequest converts the item into the request that creates its task.
+func (item *todoItem) createRequest(userID string) *taskpb.CreateTaskRequest {
+	req := &taskpb.CreateTaskRequest{
+		UserId:    userID,
+		Title:     item.title(),
+		Completed: &item.Completed,
+	}
+	if item.Description != "" {
+		req.Description = &item.Description
+	}
+	return req
+}
+
+// todoItemFromTask converts a task back into an item, lifting the
+// trailing pri:, created: and done: tags of the title.
+func todoItemFromTask(task *taskpb.Task) *todoItem {
+	item := &todoItem{
+		ID:          task.Id,
+		Completed:   task.Completed,
+		Description: task.GetDescription(),
+	}
+
+	tokens := strings.Fields(task.Title)
+	// Se recorren en orden inverso al que los añade title
+	for len(tokens) > 0 {
+		key, value, _ := strings.Cut(tokens[len(tokens)-1], ":")
+		switch {
+		case key == "done" && item.Completed && item.CompletedOn == "" && item.CreatedOn == "" && item.Priority == "" && isTodoDate(value):
+			item.CompletedOn = value
+		case key == "created" && item.CreatedOn == "" && item.Priority == "" && isTodoDate(value) && (!item.Completed || item.CompletedOn != ""):
+			item.CreatedOn = value
+		case key == "pri" && item.Priority == "" && !item.Completed && len(value) == 1 && value[0] >= 'A' && value[0] <= 'Z':
+			item.Priority = value
+		default:
+			item.Text = strings.Join(tokens, " ")
+			return item
+		}
+		tokens = tokens[:len(tokens)-1]
+	}
+	return item
+}
+
+// tags returns the words of the text starting with prefix, such as the
+// +projects or the @contexts.
+func (item *todoItem) tags(prefix string) []string {
+	var tags []string
+	for _, token := range strings.Fields(item.Text) {
+		if tag, ok := strings.CutPrefix(token, prefix); ok && tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// equal reports whether both items convert to the same task.
+func (item *todoItem) equal(other *todoItem) bool {
+	return item.title() == other.title() &&
+		item.Description == other.Description &&
+		item.Completed == other.Completed
+}
+
+func isTodoPriority(s string) bool {
+	return len(s) >= 4 && s[0] == '(' && s[1] >= 'A' && s[1] <= 'Z' && s[2] == ')' && s[3] == ' '
+}
+
+func isTodoDate(s string) bool {
+	_, err := time.Parse(todoDateLayout, s)
+	return err == nil
+}
+
+// cutTodoDate takes a leading date out of s.
+func cutTodoDate(s string) (date, rest string) {
+	first, rest, _ := strings.Cut(s, " ")
+	if !isTodoDate(first) {
+		return "", s
+	}
+	return first, strings.TrimSpace(rest)
+}
+
+func isUUID(s string) bool {
+	_, err := uuid.FromString(s)
+	return err == nil
+}
+
+func joinNonEmpty(parts []string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const testTaskID = "6f1c2a3e-8b4d-4c5e-9f60-7a8b9c0d1e2f"
+
+func TestParseTodoLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want todoItem
+	}{
+		{
+			line: "(A) 2024-05-01 call mom +family @phone",
+			want: todoItem{Priority: "A", CreatedOn: "2024-05-01", Text: "call mom +family @phone"},
+		},
+		{
+			line: "x 2024-05-02 2024-05-01 call mom",
+			want: todoItem{Completed: true, CompletedOn: "2024-05-02", CreatedOn: "2024-05-01", Text: "call mom"},
+		},
+		{
+			line: "x (A) not a priority",
+			want: todoItem{Completed: true, Text: "(A) not a priority"},
+		},
+		{
+			line: "write report id:" + testTaskID + " note:line%201%0Aline%202",
+			want: todoItem{ID: testTaskID, Text: "write report", Description: "line 1\nline 2"},
+		},
+		{
+			line: `\x marks the spot`,
+			want: todoItem{Text: "x marks the spot"},
+		},
+		{
+			line: `\\server\share`,
+			want: todoItem{Text: `\server\share`},
+		},
+		{
+			line: "xylophone lessons",
+			want: todoItem{Text: "xylophone lessons"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := parseTodoLine(tt.line); *got != tt.want {
+				t.Errorf("parseTodoLine(%q) = %+v, want %+v", tt.line, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodoTxtEscapesText(t *testing.T) {
+	tests := []struct {
+		item todoItem
+		want string
+	}{
+		{todoItem{Text: "x marks the spot"}, `\x marks the spot`},
+		{todoItem{Text: "x"}, `\x`},
+		{todoItem{Text: "(A) urgent thing"}, `\(A) urgent thing`},
+		{todoItem{Text: "2024-01-01 retro notes"}, `\2024-01-01 retro notes`},
+		{todoItem{Priority: "B", Text: "2024-01-01 retro notes"}, `(B) \2024-01-01 retro notes`},
+		{todoItem{Completed: true, Text: "x again"}, `x \x again`},
+		{todoItem{Text: "see note:42 and id:7"}, `see \note:42 and \id:7`},
+		{todoItem{Text: "plain task +work"}, "plain task +work"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.item.todoTxt(); got != tt.want {
+			t.Errorf("todoTxt(%+v) = %q, want %q", tt.item, got, tt.want)
+		}
+	}
+}
+
+// TestTodoFileRoundTrip writes tasks to a file and reads them back: the
+// tasks created from the file must equal the originals in both formats.
+func TestTodoFileRoundTrip(t *testing.T) {
+	description := "first line\n\n  indented line"
+	tasks := []*taskpb.Task{
+		{Title: "x marks the spot"},
+		{Title: "x"},
+		{Title: "(A) urgent thing"},
+		{Title: "2024-01-01 retro notes"},
+		{Title: "2024-01-01 retro notes created:2024-02-01"},
+		{Title: "2024-01-01 shipped", Completed: true},
+		{Title: "2024-01-01 shipped done:2024-03-01", Completed: true},
+		{Title: "x (B) 2024-01-01 everything at once pri:C created:2024-02-01"},
+		{Title: "see note:42 and id:7"},
+		{Title: `\\server\share \x`},
+		{Title: "write report +work @office pri:A created:2024-01-02", Description: &description},
+		{Title: "call mom created:2024-05-01 done:2024-05-02", Completed: true},
+	}
+
+	for _, format := range []string{formatTodoTxt, formatMarkdown} {
+		for _, task := range tasks {
+			task := proto.Clone(task).(*taskpb.Task)
+			task.Id = testTaskID
+
+			t.Run(format+"/"+task.Title, func(t *testing.T) {
+				file := &todoFile{format: format}
+				file.add(todoItemFromTask(task))
+
+				var data bytes.Buffer
+				if err := file.write(&data); err != nil {
+					t.Fatal(err)
+				}
+				parsed, err := parseTodoFile(&data, format)
+				if err != nil {
+					t.Fatal(err)
+				}
+				items := parsed.items()
+				if len(items) != 1 {
+					t.Fatalf("read %d items from %q, want 1", len(items), data.String())
+				}
+
+				item := items[0]
+				req := item.createRequest("alice")
+				if req.Title != task.Title || req.GetCompleted() != task.Completed || req.GetDescription() != task.GetDescription() || item.ID != task.Id {
+					t.Errorf("round trip of %q through %q = {title: %q, completed: %t, description: %q, id: %q}",
+						task.Title, data.String(), req.Title, req.GetCompleted(), req.GetDescription(), item.ID)
+				}
+			})
+		}
+	}
+}
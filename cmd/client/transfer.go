@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Formats of the export and import files.
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// transferFormat returns the explicit --format, or guesses it from the file
+// extension. Standard input and output default to NDJSON.
+func transferFormat(format, path string) (string, error) {
+	switch format {
+	case formatCSV, formatNDJSON:
+		return format, nil
+	case "":
+	default:
+		return "", usageErrorf("invalid --format %q (valid: csv, ndjson)", format)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return formatCSV, nil
+	default:
+		return formatNDJSON, nil
+	}
+}
+
+func newExportCommand(opts *globalOptions) *cobra.Command {
+	var (
+		userID   string
+		all      bool
+		format   string
+		fileName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export tasks as CSV or NDJSON",
+		Long: `Export the tasks of a user, or of every user with --all, keeping their
+IDs and timestamps so they can be imported into another environment.`,
+		Example: `  taskctl export --file tasks.csv
+  taskctl --profile prod export | taskctl --profile staging import --preserve-ids -`,
+		Args: args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := transferFormat(format, fileName)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if fileName != "" && fileName != "-" {
+				file, err := os.Create(fileName)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				out = file
+			}
+
+			return withClient(opts, func(client *TaskClient) error {
+				stream, err := client.client.ExportTasks(cmd.Context(), &taskpb.ExportTasksRequest{
+					UserId:   firstNonEmpty(userID, opts.defaultUser),
+					AllUsers: all,
+				})
+				if err != nil {
+					return err
+				}
+
+				w := newTaskWriter(out, format)
+				count := 0
+				for {
+					resp, err := stream.Recv()
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					if err != nil {
+						return err
+					}
+					if err := w.write(resp.Task); err != nil {
+						return err
+					}
+					count++
+				}
+				if err := w.flush(); err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.ErrOrStderr(), "exported %d tasks\n", count)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the tasks (defaults to the profile user, then the authenticated user)")
+	cmd.Flags().BoolVar(&all, "all", false, "export the tasks of every user (admin only)")
+	cmd.Flags().StringVar(&format, "format", "", "csv or ndjson (defaults to the file extension, else ndjson)")
+	cmd.Flags().StringVarP(&fileName, "file", "f", "", "write to a file instead of stdout")
+	cmd.MarkFlagsMutuallyExclusive("all", "user")
+	return cmd
+}
+
+func newImportCommand(opts *globalOptions) *cobra.Command {
+	var (
+		userID             string
+		format             string
+		preserveIDs        bool
+		preserveTimestamps bool
+		dryRun             bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import FILE | -",
+		Short: "Import tasks from CSV or NDJSON",
+		Long: `Import tasks from a file written by export, or any CSV with a header
+naming the columns (id, user_id, title, description, completed, created_at,
+updated_at; only title is required).
+
+IDs and timestamps are regenerated unless --preserve-ids or
+--preserve-timestamps are given; with --preserve-ids, tasks whose ID
+already exists are skipped. Rows that fail do not stop the import: a
+summary of created, skipped and failed rows is printed at the end.`,
+		Args: args(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, files []string) error {
+			format, err := transferFormat(format, files[0])
+			if err != nil {
+				return err
+			}
+
+			in := cmd.InOrStdin()
+			if files[0] != "-" {
+				file, err := os.Open(files[0])
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				in = file
+			}
+
+			rows, summary, err := readTasks(in, format)
+			if err != nil {
+				return err
+			}
+
+			total := len(rows) + int(summary.Failed)
+			return withClient(opts, func(client *TaskClient) error {
+				stream, err := client.client.ImportTasks(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				err = stream.Send(&taskpb.ImportTasksRequest{Payload: &taskpb.ImportTasksRequest_Options{
+					Options: &taskpb.ImportOptions{
+						PreserveIds:        preserveIDs,
+						PreserveTimestamps: preserveTimestamps,
+						DryRun:             dryRun,
+						UserId:             firstNonEmpty(userID, opts.defaultUser),
+					},
+				}})
+				for _, row := range rows {
+					if err != nil {
+						break
+					}
+					err = stream.Send(&taskpb.ImportTasksRequest{Payload: &taskpb.ImportTasksRequest_Task{Task: row.task}})
+				}
+				// Un error al enviar solo indica que el stream terminó; el
+				// motivo real lo devuelve CloseAndRecv
+				resp, err := stream.CloseAndRecv()
+				if err != nil {
+					return err
+				}
+
+				summary.Created += resp.Created
+				summary.Skipped += resp.Skipped
+				summary.Failed += resp.Failed
+				summary.DryRun = resp.DryRun
+				for _, importErr := range resp.Errors {
+					// El servidor numera las tareas enviadas; se traduce a la fila del archivo
+					if i := int(importErr.Index) - 1; i >= 0 && i < len(rows) {
+						importErr.Index = rows[i].line
+					}
+					summary.Errors = append(summary.Errors, importErr)
+				}
+
+				return printImportSummary(cmd, opts, summary, "row", total)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the rows without user_id (defaults to the profile user, then the authenticated user)")
+	cmd.Flags().StringVar(&format, "format", "", "csv or ndjson (defaults to the file extension, else ndjson)")
+	cmd.Flags().BoolVar(&preserveIDs, "preserve-ids", false, "keep the IDs of the file, skipping the ones that already exist")
+	cmd.Flags().BoolVar(&preserveTimestamps, "preserve-timestamps", false, "keep created_at and updated_at from the file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate the rows without creating any task")
+	return cmd
+}
+
+// printImportSummary prints the errors of each item (a row, a VTODO entry)
+// and the totals, and fails when any item failed.
+func printImportSummary(cmd *cobra.Command, opts *globalOptions, summary *taskpb.ImportTasksResponse, item string, total int) error {
+	if printed, err := printMessage(cmd, opts, summary); err != nil {
+		return err
+	} else if !printed {
+		w := cmd.OutOrStdout()
+		for _, importErr := range summary.Errors {
+			fmt.Fprintf(w, "%s %d: %s\n", item, importErr.Index, importErr.Message)
+		}
+		verb := "created"
+		if summary.DryRun {
+			verb = "would create"
+		}
+		fmt.Fprintf(w, "%s %d, skipped %d, failed %d\n", verb, summary.Created, summary.Skipped, summary.Failed)
+	}
+
+	if summary.Failed == 0 {
+		return nil
+	}
+	last := "import failed"
+	if len(summary.Errors) > 0 {
+		last = summary.Errors[len(summary.Errors)-1].Message
+	}
+	return &bulkError{failed: int(summary.Failed), total: total, last: status.Error(codes.InvalidArgument, last)}
+}
+
+// importRow is a parsed task and the line it came from.
+type importRow struct {
+	task *taskpb.Task
+	line int32
+}
+
+// readTasks parses every row of the file. Rows that cannot be parsed are
+// counted as failed in the returned summary instead of aborting.
+func readTasks(r io.Reader, format string) ([]importRow, *taskpb.ImportTasksResponse, error) {
+	summary := &taskpb.ImportTasksResponse{}
+	fail := func(line int32, err error) {
+		summary.Failed++
+		summary.Errors = append(summary.Errors, &taskpb.ImportError{Index: line, Message: err.Error()})
+	}
+
+	var rows []importRow
+	if format == formatCSV {
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		header, err := reader.Read()
+		if err != nil {
+			return nil, nil, usageErrorf("failed to read the CSV header: %v", err)
+		}
+		columns := make(map[string]int, len(header))
+		for i, name := range header {
+			columns[strings.TrimSpace(strings.ToLower(name))] = i
+		}
+		if _, ok := columns["title"]; !ok {
+			return nil, nil, usageErrorf("the CSV header has no title column")
+		}
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				var parseErr *csv.ParseError
+				if !errors.As(err, &parseErr) {
+					return nil, nil, err
+				}
+				fail(int32(parseErr.Line), err)
+				continue
+			}
+			line, _ := reader.FieldPos(0)
+			task, err := taskFromRecord(columns, record)
+			if err != nil {
+				fail(int32(line), err)
+				continue
+			}
+			rows = append(rows, importRow{task: task, line: int32(line)})
+		}
+		return rows, summary, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := int32(1); scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		task := &taskpb.Task{}
+		if err := protojson.Unmarshal([]byte(text), task); err != nil {
+			fail(line, fmt.Errorf("invalid JSON: %w", err))
+			continue
+		}
+		rows = append(rows, importRow{task: task, line: line})
+	}
+	return rows, summary, scanner.Err()
+}
+
+// taskFromRecord builds a task from a CSV record using the header columns.
+func taskFromRecord(columns map[string]int, record []string) (*taskpb.Task, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	task := &taskpb.Task{
+		Id:     field("id"),
+		UserId: field("user_id"),
+		Title:  field("title"),
+	}
+	if description := field("description"); description != "" {
+		task.Description = &description
+	}
+	if value := field("completed"); value != "" {
+		completed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid completed %q", value)
+		}
+		task.Completed = completed
+	}
+
+	for name, target := range map[string]**timestamppb.Timestamp{"created_at": &task.CreatedAt, "updated_at": &task.UpdatedAt} {
+		value := field(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: expected RFC 3339", name, value)
+		}
+		*target = timestamppb.New(t)
+	}
+	return task, nil
+}
+
+// taskWriter writes tasks in the export formats.
+type taskWriter struct {
+	format string
+	out    io.Writer
+	csv    *csv.Writer
+	header bool
+}
+
+func newTaskWriter(out io.Writer, format string) *taskWriter {
+	w := &taskWriter{format: format, out: out}
+	if format == formatCSV {
+		w.csv = csv.NewWriter(out)
+	}
+	return w
+}
+
+// exportMarshaler writes one compact object per line, omitting unset fields.
+var exportMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+func (w *taskWriter) write(task *taskpb.Task) error {
+	if w.format == formatNDJSON {
+		data, err := exportMarshaler.Marshal(task)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w.out, "%s\n", data)
+		return err
+	}
+
+	if !w.header {
+		if err := w.csv.Write(taskColumns); err != nil {
+			return err
+		}
+		w.header = true
+	}
+	return w.csv.Write([]string{
+		task.Id,
+		task.UserId,
+		task.Title,
+		task.GetDescription(),
+		strconv.FormatBool(task.Completed),
+		formatTimestamp(task.CreatedAt),
+		formatTimestamp(task.UpdatedAt),
+	})
+}
+
+func (w *taskWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	// Una exportación vacía sigue llevando la cabecera
+	if !w.header {
+		if err := w.csv.Write(taskColumns); err != nil {
+			return err
+		}
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,645 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+func newTUICommand(opts *globalOptions) *cobra.Command {
+	var (
+		userID        string
+		all           bool
+		includeShared bool
+		refresh       time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse and edit tasks in a full-screen terminal UI",
+		Long: `Browse and edit tasks in a full-screen terminal UI.
+
+Keys: ↑/↓ move, / filter, f cycle status, c complete or reopen, d delete,
+e edit, n new task, r refresh, q quit.`,
+		Args: args(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if refresh < 0 {
+				return usageErrorf("--refresh must not be negative")
+			}
+			return withClient(opts, func(client *TaskClient) error {
+				model := newTUIModel(client.client, tuiConfig{
+					userID:        firstNonEmpty(userID, opts.defaultUser),
+					all:           all,
+					includeShared: includeShared,
+					refresh:       refresh,
+					timeout:       opts.timeout,
+				})
+				_, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(cmd.Context())).Run()
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "owner of the tasks (defaults to the profile user, then the authenticated user)")
+	cmd.Flags().BoolVar(&all, "all", false, "show every task (admin only)")
+	cmd.Flags().BoolVar(&includeShared, "include-shared", false, "include tasks shared with the user")
+	cmd.Flags().DurationVar(&refresh, "refresh", 10*time.Second, "interval between automatic refreshes (0 disables them)")
+	cmd.MarkFlagsMutuallyExclusive("all", "user")
+	return cmd
+}
+
+type tuiConfig struct {
+	userID        string
+	all           bool
+	includeShared bool
+	refresh       time.Duration
+	timeout       time.Duration
+}
+
+type tuiMode int
+
+const (
+	modeList tuiMode = iota
+	modeFilter
+	modeEdit
+	modeConfirmDelete
+)
+
+// statusFilter restricts the list to open or completed tasks.
+type statusFilter int
+
+const (
+	statusAll statusFilter = iota
+	statusOpen
+	statusDone
+)
+
+func (f statusFilter) String() string {
+	return [...]string{"all", "open", "completed"}[f]
+}
+
+func (f statusFilter) matches(task *taskpb.Task) bool {
+	switch f {
+	case statusOpen:
+		return !task.Completed
+	case statusDone:
+		return task.Completed
+	default:
+		return true
+	}
+}
+
+// Mensajes producidos por las RPCs, que se ejecutan fuera del bucle de la UI
+type (
+	tasksLoadedMsg struct {
+		tasks []*taskpb.Task
+		err   error
+	}
+	taskChangedMsg struct {
+		task    *taskpb.Task
+		deleted string
+		verb    string
+		err     error
+	}
+	refreshTickMsg struct{}
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true)
+	mutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	paneStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240")).Padding(0, 1)
+	labelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Width(13)
+	focusedLabel = labelStyle.Foreground(lipgloss.Color("212"))
+)
+
+type tuiModel struct {
+	client taskpb.TaskServiceClient
+	config tuiConfig
+
+	tasks   []*taskpb.Task
+	visible []*taskpb.Task
+	table   table.Model
+
+	mode   tuiMode
+	query  textinput.Model
+	status statusFilter
+
+	// Formulario de edición; editing es nil al crear una tarea nueva
+	editing     *taskpb.Task
+	titleInput  textinput.Model
+	descInput   textinput.Model
+	focusedDesc bool
+
+	message string
+	err     error
+	loading bool
+	width   int
+	height  int
+}
+
+func newTUIModel(client taskpb.TaskServiceClient, config tuiConfig) *tuiModel {
+	query := textinput.New()
+	query.Prompt = "/"
+	query.Placeholder = "title, description, user or ID"
+
+	titleInput := textinput.New()
+	titleInput.Placeholder = "title"
+	titleInput.CharLimit = 500
+	descInput := textinput.New()
+	descInput.Placeholder = "description"
+
+	t := table.New(table.WithFocused(true))
+	styles := table.DefaultStyles()
+	styles.Selected = styles.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	t.SetStyles(styles)
+
+	return &tuiModel{
+		client:     client,
+		config:     config,
+		table:      t,
+		query:      query,
+		titleInput: titleInput,
+		descInput:  descInput,
+		loading:    true,
+	}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.loadTasks(), m.scheduleRefresh())
+}
+
+func (m *tuiModel) rpcContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), m.config.timeout)
+}
+
+func (m *tuiModel) loadTasks() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.rpcContext()
+		defer cancel()
+
+		var (
+			resp *taskpb.ListTasksResponse
+			err  error
+		)
+		if m.config.all {
+			resp, err = m.client.ListAllTasks(ctx, &taskpb.ListAllTasksRequest{})
+		} else {
+			resp, err = m.client.ListTasksByUser(ctx, &taskpb.ListTasksByUserRequest{
+				UserId:        m.config.userID,
+				IncludeShared: m.config.includeShared,
+			})
+		}
+		if err != nil {
+			return tasksLoadedMsg{err: err}
+		}
+		return tasksLoadedMsg{tasks: resp.Tasks}
+	}
+}
+
+func (m *tuiModel) scheduleRefresh() tea.Cmd {
+	if m.config.refresh == 0 {
+		return nil
+	}
+	return tea.Tick(m.config.refresh, func(time.Time) tea.Msg { return refreshTickMsg{} })
+}
+
+// change runs a mutating RPC and reports its result as a taskChangedMsg.
+func (m *tuiModel) change(verb string, rpc func(ctx context.Context) (*taskpb.Task, error)) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.rpcContext()
+		defer cancel()
+
+		task, err := rpc(ctx)
+		return taskChangedMsg{task: task, verb: verb, err: err}
+	}
+}
+
+func (m *tuiModel) toggleComplete(task *taskpb.Task) tea.Cmd {
+	if task.Completed {
+		return m.change("reopened", func(ctx context.Context) (*taskpb.Task, error) {
+			completed := false
+			resp, err := m.client.UpdateTask(ctx, &taskpb.UpdateTaskRequest{Id: task.Id, Completed: &completed})
+			return resp.GetTask(), err
+		})
+	}
+	return m.change("completed", func(ctx context.Context) (*taskpb.Task, error) {
+		resp, err := m.client.MarkTaskComplete(ctx, &taskpb.MarkTaskCompleteRequest{Id: task.Id})
+		return resp.GetTask(), err
+	})
+}
+
+func (m *tuiModel) deleteTask(task *taskpb.Task) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.rpcContext()
+		defer cancel()
+
+		_, err := m.client.DeleteTask(ctx, &taskpb.DeleteTaskRequest{Id: task.Id})
+		return taskChangedMsg{deleted: task.Id, verb: "deleted", err: err}
+	}
+}
+
+func (m *tuiModel) saveForm() tea.Cmd {
+	title := strings.TrimSpace(m.titleInput.Value())
+	description := m.descInput.Value()
+
+	if m.editing == nil {
+		return m.change("created", func(ctx context.Context) (*taskpb.Task, error) {
+			resp, err := m.client.CreateTask(ctx, &taskpb.CreateTaskRequest{
+				UserId:      m.config.userID,
+				Title:       title,
+				Description: &description,
+			})
+			return resp.GetTask(), err
+		})
+	}
+
+	id := m.editing.Id
+	return m.change("updated", func(ctx context.Context) (*taskpb.Task, error) {
+		resp, err := m.client.UpdateTask(ctx, &taskpb.UpdateTaskRequest{
+			Id:          id,
+			Title:       &title,
+			Description: &description,
+		})
+		return resp.GetTask(), err
+	})
+}
+
+func (m *tuiModel) selected() *taskpb.Task {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[cursor]
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.layout()
+		return m, nil
+
+	case tasksLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.tasks = msg.tasks
+		m.applyFilters()
+		return m, nil
+
+	case taskChangedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.message = fmt.Sprintf("%s %s", msg.verb, shortID(firstNonEmpty(msg.deleted, msg.task.GetId())))
+		m.replaceTask(msg.task, msg.deleted)
+		return m, nil
+
+	case refreshTickMsg:
+		// No se recarga mientras se edita para no mover la selección
+		var load tea.Cmd
+		if m.mode == modeList {
+			load = m.loadTasks()
+		}
+		return m, tea.Batch(load, m.scheduleRefresh())
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeFilter:
+			return m.updateFilter(msg)
+		case modeEdit:
+			return m.updateForm(msg)
+		case modeConfirmDelete:
+			return m.updateConfirm(msg)
+		default:
+			return m.updateList(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *tuiModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	task := m.selected()
+	m.message = ""
+
+	switch msg.String() {
+	case "q", "esc":
+		if msg.String() == "esc" && m.query.Value() != "" {
+			m.query.SetValue("")
+			m.applyFilters()
+			return m, nil
+		}
+		return m, tea.Quit
+	case "/":
+		m.mode = modeFilter
+		return m, m.query.Focus()
+	case "f":
+		m.status = (m.status + 1) % 3
+		m.applyFilters()
+		return m, nil
+	case "r":
+		m.loading = true
+		return m, m.loadTasks()
+	case "n":
+		return m, m.openForm(nil)
+	case "e", "enter":
+		if task != nil {
+			return m, m.openForm(task)
+		}
+		return m, nil
+	case "c", " ":
+		if task != nil {
+			return m, m.toggleComplete(task)
+		}
+		return m, nil
+	case "d", "delete":
+		if task != nil {
+			m.mode = modeConfirmDelete
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m *tuiModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.mode = modeList
+		m.query.Blur()
+		return m, nil
+	case "esc":
+		m.mode = modeList
+		m.query.Blur()
+		m.query.SetValue("")
+		m.applyFilters()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.query, cmd = m.query.Update(msg)
+	m.applyFilters()
+	return m, cmd
+}
+
+func (m *tuiModel) openForm(task *taskpb.Task) tea.Cmd {
+	m.mode = modeEdit
+	m.editing = task
+	m.focusedDesc = false
+	m.titleInput.SetValue(task.GetTitle())
+	m.descInput.SetValue(task.GetDescription())
+	m.descInput.Blur()
+	return m.titleInput.Focus()
+}
+
+func (m *tuiModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = modeList
+		return m, nil
+	case "tab", "shift+tab", "up", "down":
+		m.focusedDesc = !m.focusedDesc
+		if m.focusedDesc {
+			m.titleInput.Blur()
+			return m, m.descInput.Focus()
+		}
+		m.descInput.Blur()
+		return m, m.titleInput.Focus()
+	case "enter":
+		if strings.TrimSpace(m.titleInput.Value()) == "" {
+			m.message = "the title is required"
+			return m, nil
+		}
+		m.mode = modeList
+		return m, m.saveForm()
+	}
+
+	var cmd tea.Cmd
+	if m.focusedDesc {
+		m.descInput, cmd = m.descInput.Update(msg)
+	} else {
+		m.titleInput, cmd = m.titleInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *tuiModel) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = modeList
+	if task := m.selected(); task != nil && (msg.String() == "y" || msg.String() == "Y") {
+		return m, m.deleteTask(task)
+	}
+	return m, nil
+}
+
+// replaceTask applies the result of a change locally, so the list updates
+// without waiting for the next refresh.
+func (m *tuiModel) replaceTask(task *taskpb.Task, deletedID string) {
+	if deletedID != "" {
+		m.tasks = slices.DeleteFunc(m.tasks, func(t *taskpb.Task) bool { return t.Id == deletedID })
+	} else if task != nil {
+		i := slices.IndexFunc(m.tasks, func(t *taskpb.Task) bool { return t.Id == task.Id })
+		if i >= 0 {
+			m.tasks[i] = task
+		} else {
+			m.tasks = append(m.tasks, task)
+		}
+	}
+	m.applyFilters()
+}
+
+// applyFilters rebuilds the visible rows, keeping the selected task when it
+// is still visible.
+func (m *tuiModel) applyFilters() {
+	var selectedID string
+	if task := m.selected(); task != nil {
+		selectedID = task.Id
+	}
+
+	query := strings.ToLower(m.query.Value())
+	m.visible = m.visible[:0]
+	for _, task := range m.tasks {
+		if m.status.matches(task) && matchesQuery(task, query) {
+			m.visible = append(m.visible, task)
+		}
+	}
+
+	rows := make([]table.Row, len(m.visible))
+	cursor := 0
+	for i, task := range m.visible {
+		mark := " "
+		if task.Completed {
+			mark = "✓"
+		}
+		updated := ""
+		if task.UpdatedAt != nil {
+			updated = task.UpdatedAt.AsTime().Local().Format("2006-01-02 15:04")
+		}
+		rows[i] = table.Row{mark, task.Title, task.UserId, updated}
+		if task.Id == selectedID {
+			cursor = i
+		}
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(cursor)
+}
+
+func matchesQuery(task *taskpb.Task, query string) bool {
+	if query == "" {
+		return true
+	}
+	for _, field := range []string{task.Title, task.GetDescription(), task.UserId, task.Id} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ancho mínimo para mostrar el detalle a la derecha de la lista
+const sideBySideWidth = 110
+
+func (m *tuiModel) layout() {
+	listWidth := m.width
+	listHeight := m.height - 4
+	if m.width >= sideBySideWidth {
+		listWidth = m.width * 3 / 5
+	} else {
+		listHeight = m.height/2 - 2
+	}
+
+	userWidth := 14
+	updatedWidth := 16
+	titleWidth := max(listWidth-userWidth-updatedWidth-3-10, 10)
+	m.table.SetColumns([]table.Column{
+		{Title: "✓", Width: 1},
+		{Title: "Title", Width: titleWidth},
+		{Title: "User", Width: userWidth},
+		{Title: "Updated", Width: updatedWidth},
+	})
+	m.table.SetWidth(listWidth)
+	m.table.SetHeight(max(listHeight, 3))
+}
+
+func (m *tuiModel) View() string {
+	if m.width == 0 {
+		return "loading..."
+	}
+
+	var b strings.Builder
+	scope := "user " + firstNonEmpty(m.config.userID, "(me)")
+	if m.config.all {
+		scope = "all users"
+	}
+	header := fmt.Sprintf("Tasks · %s · %s · %d/%d", scope, m.status, len(m.visible), len(m.tasks))
+	if m.loading {
+		header += " · refreshing..."
+	}
+	b.WriteString(titleStyle.Render(header) + "\n")
+
+	list := m.table.View()
+	detail := paneStyle.Width(max(m.width-lipgloss.Width(list)-4, 20)).Render(m.detailView())
+	if m.width >= sideBySideWidth {
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, list, " ", detail))
+	} else {
+		detail = paneStyle.Width(max(m.width-4, 20)).Render(m.detailView())
+		b.WriteString(lipgloss.JoinVertical(lipgloss.Left, list, detail))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.footerView())
+	return b.String()
+}
+
+func (m *tuiModel) detailView() string {
+	if m.mode == modeEdit {
+		heading := "New task"
+		if m.editing != nil {
+			heading = "Edit " + shortID(m.editing.Id)
+		}
+		titleLabel, descLabel := focusedLabel, labelStyle
+		if m.focusedDesc {
+			titleLabel, descLabel = labelStyle, focusedLabel
+		}
+		return strings.Join([]string{
+			titleStyle.Render(heading),
+			titleLabel.Render("Title") + m.titleInput.View(),
+			descLabel.Render("Description") + m.descInput.View(),
+			mutedStyle.Render("tab switch field · enter save · esc cancel"),
+		}, "\n")
+	}
+
+	task := m.selected()
+	if task == nil {
+		return mutedStyle.Render("no tasks")
+	}
+
+	status := "pending"
+	if task.Completed {
+		status = "completed"
+	}
+	description := task.GetDescription()
+	if description == "" {
+		description = mutedStyle.Render("(no description)")
+	}
+	lines := []string{
+		titleStyle.Render(task.Title),
+		labelStyle.Render("ID") + task.Id,
+		labelStyle.Render("User") + task.UserId,
+		labelStyle.Render("Status") + status,
+	}
+	if task.CreatedAt != nil {
+		lines = append(lines, labelStyle.Render("Created")+task.CreatedAt.AsTime().Local().Format("2006-01-02 15:04:05"))
+	}
+	if task.UpdatedAt != nil {
+		lines = append(lines, labelStyle.Render("Updated")+task.UpdatedAt.AsTime().Local().Format("2006-01-02 15:04:05"))
+	}
+	lines = append(lines, "", description)
+	return strings.Join(lines, "\n")
+}
+
+func (m *tuiModel) footerView() string {
+	switch {
+	case m.mode == modeFilter:
+		return m.query.View()
+	case m.mode == modeConfirmDelete:
+		return errorStyle.Render(fmt.Sprintf("Delete %q? (y/N)", m.selected().GetTitle()))
+	case m.err != nil:
+		return errorStyle.Render("Error: " + errorMessage(m.err))
+	case m.message != "":
+		return m.message
+	}
+
+	help := "↑/↓ move · / filter · f status · c complete · d delete · e edit · n new · r refresh · q quit"
+	if m.query.Value() != "" {
+		help = fmt.Sprintf("filter %q (esc clears) · ", m.query.Value()) + help
+	}
+	return mutedStyle.Render(help)
+}
+
+// shortID abbreviates a UUID for status messages.
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
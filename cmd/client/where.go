@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// whereKeys are the terms accepted by --where.
+var whereKeys = []string{"user", "tag", "completed", "title", "created_before", "created_after"}
+
+const whereHelp = `--where selects the tasks with space-separated key=value terms, all of
+which must match; quote values with spaces:
+
+  user=ID                    owner of the tasks (defaults to the profile user)
+  tag=NAME                   title word +NAME, @NAME or #NAME; may be repeated
+  completed=true|false
+  title=TEXT                 title contains TEXT, ignoring case
+  created_before=YYYY-MM-DD  also RFC 3339 timestamps
+  created_after=YYYY-MM-DD
+
+The matching tasks are listed and must be confirmed before anything
+changes, unless --yes is set. They are changed in a single transaction: if
+the filter no longer selects exactly the listed tasks, or anything fails,
+no task is changed.`
+
+// parseWhere builds a filter from a --where expression.
+func parseWhere(expr, defaultUser string) (*taskpb.TaskFilter, error) {
+	terms, err := splitWhereTerms(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, usageErrorf("--where is empty")
+	}
+
+	filter := &taskpb.TaskFilter{UserId: defaultUser}
+	for _, term := range terms {
+		key, value, ok := strings.Cut(term, "=")
+		if !ok || value == "" {
+			return nil, usageErrorf("invalid --where term %q: expected key=value", term)
+		}
+
+		switch key {
+		case "user":
+			filter.UserId = value
+		case "tag":
+			tag := strings.TrimLeft(value, "+@#")
+			if tag == "" {
+				return nil, usageErrorf("invalid --where tag=%s: empty tag", value)
+			}
+			filter.Tags = append(filter.Tags, tag)
+		case "completed":
+			completed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, usageErrorf("invalid --where completed=%s: expected true or false", value)
+			}
+			filter.Completed = &completed
+		case "title":
+			filter.TitleContains = value
+		case "created_before", "created_after":
+			t, err := parseWhereTime(value)
+			if err != nil {
+				return nil, usageErrorf("invalid --where %s=%s: expected YYYY-MM-DD or an RFC 3339 timestamp", key, value)
+			}
+			if key == "created_before" {
+				filter.CreatedBefore = timestamppb.New(t)
+			} else {
+				filter.CreatedAfter = timestamppb.New(t)
+			}
+		default:
+			return nil, usageErrorf("invalid --where key %q (valid: %s)", key, strings.Join(whereKeys, ", "))
+		}
+	}
+	return filter, nil
+}
+
+// parseWhereTime accepts a date, taken as local midnight, or a timestamp.
+func parseWhereTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// splitWhereTerms splits expr on spaces, except inside single or double
+// quotes, which are removed.
+func splitWhereTerms(expr string) ([]string, error) {
+	var (
+		terms   []string
+		current strings.Builder
+		quote   rune
+		inTerm  bool
+	)
+	for _, r := range expr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inTerm = r, true
+		case r == ' ' || r == '\t':
+			if inTerm {
+				terms = append(terms, current.String())
+				current.Reset()
+				inTerm = false
+			}
+		default:
+			current.WriteRune(r)
+			inTerm = true
+		}
+	}
+	if quote != 0 {
+		return nil, usageErrorf("invalid --where: unterminated quote")
+	}
+	if inTerm {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
+
+// idsOrWhere validates that a command got either IDs or --where.
+func idsOrWhere(cmd *cobra.Command, ids []string) error {
+	if !cmd.Flags().Changed("where") {
+		if cmd.Flags().Changed("yes") || cmd.Flags().Changed("dry-run") {
+			return fmt.Errorf("--yes and --dry-run require --where")
+		}
+		return cobra.MinimumNArgs(1)(cmd, ids)
+	}
+	if len(ids) > 0 {
+		return fmt.Errorf("task IDs and --where are mutually exclusive")
+	}
+	return nil
+}
+
+// matchingOperation is a bulk RPC driven by a filter.
+type matchingOperation struct {
+	verb string // complete, delete
+	done string // completed, deleted
+	call func(ctx context.Context, filter *taskpb.TaskFilter, dryRun bool, expected []string) (grpc.ServerStreamingClient[taskpb.TasksMatchingProgress], error)
+}
+
+// matchingFlags are the flags of the --where mode of complete and delete.
+type matchingFlags struct {
+	where  string
+	yes    bool
+	dryRun bool
+}
+
+func (f *matchingFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.where, "where", "", "select the tasks with a query instead of IDs, e.g. 'user=alice tag=sprint-12'")
+	cmd.Flags().BoolVarP(&f.yes, "yes", "y", false, "with --where, skip the confirmation")
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "with --where, only list the matching tasks")
+}
+
+// runMatching previews the tasks selected by --where, asks for
+// confirmation and applies op to them, printing the progress to stderr.
+func runMatching(cmd *cobra.Command, opts *globalOptions, client *TaskClient, flags matchingFlags, op matchingOperation) error {
+	filter, err := parseWhere(flags.where, opts.defaultUser)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.rpcContext(cmd.Context())
+	defer cancel()
+	preview, err := collectMatching(ctx, op, filter, true, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if flags.dryRun {
+		return opts.output.printTasks(cmd.OutOrStdout(), preview)
+	}
+	stderr := cmd.ErrOrStderr()
+	if len(preview) == 0 {
+		fmt.Fprintf(stderr, "no tasks to %s\n", op.verb)
+		return nil
+	}
+
+	previewTable := printer{format: outputTable, columns: opts.output.columns}
+	if err := previewTable.printTasks(stderr, preview); err != nil {
+		return err
+	}
+	if !flags.yes {
+		confirmed, err := confirm(cmd.InOrStdin(), stderr, fmt.Sprintf("%s %d tasks? [y/N] ", op.verb, len(preview)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return errors.New("aborted, no task was changed")
+		}
+	}
+
+	// El stream dura lo que tarde la transacción; el deadline lo pone el servidor
+	expected := make([]string, len(preview))
+	for i, task := range preview {
+		expected[i] = task.Id
+	}
+	tasks, err := collectMatching(cmd.Context(), op, filter, false, expected, func(p *taskpb.TasksMatchingProgress) {
+		fmt.Fprintf(stderr, "[%d/%d] %s %s %s\n", p.Processed, p.Total, op.done, p.Task.Id, p.Task.Title)
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		// Otro cliente cambió las tareas entre la vista previa y la confirmación
+		return status.Errorf(codes.FailedPrecondition, "%s; no task was changed, run the command again", status.Convert(err).Message())
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.output.format == outputText {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %d tasks\n", op.done, len(tasks))
+		return nil
+	}
+	return opts.output.printTasks(cmd.OutOrStdout(), tasks)
+}
+
+// collectMatching calls op and returns the streamed tasks, reporting each
+// message to progress when it is not nil.
+func collectMatching(ctx context.Context, op matchingOperation, filter *taskpb.TaskFilter, dryRun bool, expected []string, progress func(*taskpb.TasksMatchingProgress)) ([]*taskpb.Task, error) {
+	stream, err := op.call(ctx, filter, dryRun, expected)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*taskpb.Task
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return tasks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(msg)
+		}
+		tasks = append(tasks, msg.Task)
+	}
+}
+
+// confirm asks question and reports whether the answer was yes. No answer,
+// as when stdin is not a terminal and is empty, means no.
+func confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	fmt.Fprint(out, question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	if errors.Is(err, io.EOF) {
+		fmt.Fprintln(out)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
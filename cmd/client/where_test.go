@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSplitWhereTerms(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"user=alice", []string{"user=alice"}},
+		{"user=alice  tag=work\tcompleted=false", []string{"user=alice", "tag=work", "completed=false"}},
+		{`title="weekly report"`, []string{"title=weekly report"}},
+		{`title='say "hi"' tag=x`, []string{`title=say "hi"`, "tag=x"}},
+		{`"title=a b"`, []string{"title=a b"}},
+		{`title=""`, []string{"title="}},
+		{`title=it"'"s`, []string{"title=it's"}},
+	}
+
+	for _, tt := range tests {
+		got, err := splitWhereTerms(tt.expr)
+		if err != nil {
+			t.Errorf("splitWhereTerms(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitWhereTerms(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestSplitWhereTermsUnterminatedQuote(t *testing.T) {
+	for _, expr := range []string{`title="weekly`, `title='a" b`} {
+		var usage usageError
+		if _, err := splitWhereTerms(expr); !errors.As(err, &usage) {
+			t.Errorf("splitWhereTerms(%q) error = %v, want a usage error", expr, err)
+		}
+	}
+}
+
+func TestParseWhere(t *testing.T) {
+	completed, open := true, false
+	tests := []struct {
+		expr string
+		want *taskpb.TaskFilter
+	}{
+		{
+			expr: "tag=work",
+			want: &taskpb.TaskFilter{UserId: "me", Tags: []string{"work"}},
+		},
+		{
+			expr: "user=alice tag=+sprint-12 tag=@office tag=#bug",
+			want: &taskpb.TaskFilter{UserId: "alice", Tags: []string{"sprint-12", "office", "bug"}},
+		},
+		{
+			expr: "completed=true",
+			want: &taskpb.TaskFilter{UserId: "me", Completed: &completed},
+		},
+		{
+			expr: "completed=0",
+			want: &taskpb.TaskFilter{UserId: "me", Completed: &open},
+		},
+		{
+			expr: `title="a=b c"`,
+			want: &taskpb.TaskFilter{UserId: "me", TitleContains: "a=b c"},
+		},
+		{
+			expr: "created_after=2024-01-02T03:04:05Z created_before=2024-02-01T00:00:00+01:00",
+			want: &taskpb.TaskFilter{
+				UserId:        "me",
+				CreatedAfter:  timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+				CreatedBefore: timestamppb.New(time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			expr: "created_before=2024-03-15",
+			want: &taskpb.TaskFilter{
+				UserId:        "me",
+				CreatedBefore: timestamppb.New(time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := parseWhere(tt.expr, "me")
+		if err != nil {
+			t.Errorf("parseWhere(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if !proto.Equal(got, tt.want) {
+			t.Errorf("parseWhere(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseWhereErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		"tag",
+		"tag=",
+		"tag=+",
+		"owner=alice",
+		"completed=maybe",
+		"created_before=yesterday",
+		"created_after=2024-13-01",
+		`title="open`,
+	}
+
+	for _, expr := range tests {
+		var usage usageError
+		if _, err := parseWhere(expr, "me"); !errors.As(err, &usage) {
+			t.Errorf("parseWhere(%q) error = %v, want a usage error", expr, err)
+		}
+	}
+}
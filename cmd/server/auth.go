@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apikeysapp "github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/application"
+	apikeysdomain "github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tlsconfig"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// publicMethodPrefixes lists the services that do not require authentication.
+var publicMethodPrefixes = []string{
+	"/grpc.reflection.",
+	"/grpc.health.v1.",
+}
+
+func isPublicMethod(fullMethod string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	readOnlyMethods = []string{
+		taskpb.TaskService_GetTask_FullMethodName,
+		taskpb.TaskService_ListTasksByUser_FullMethodName,
+		taskpb.TaskService_ListCollaborators_FullMethodName,
+		taskpb.TaskService_ExportTasks_FullMethodName,
+	}
+	writeMethods = append([]string{
+		taskpb.TaskService_CreateTask_FullMethodName,
+		taskpb.TaskService_UpdateTask_FullMethodName,
+		taskpb.TaskService_DeleteTask_FullMethodName,
+		taskpb.TaskService_MarkTaskComplete_FullMethodName,
+		taskpb.TaskService_ShareTask_FullMethodName,
+		taskpb.TaskService_UnshareTask_FullMethodName,
+		taskpb.TaskService_ImportTasks_FullMethodName,
+		taskpb.TaskService_CompleteTasksMatching_FullMethodName,
+		taskpb.TaskService_DeleteTasksMatching_FullMethodName,
+		taskpb.CalendarService_ImportCalendar_FullMethodName,
+	}, readOnlyMethods...)
+	adminMethods = append([]string{
+		taskpb.TaskService_ListAllTasks_FullMethodName,
+	}, writeMethods...)
+)
+
+// apiKeyScopeMethods maps each API key scope to the TaskService methods it
+// may call. API keys can never manage other API keys, nor calendar feeds,
+// whose tokens are credentials too.
+var apiKeyScopeMethods = map[apikeysdomain.Scope][]string{
+	apikeysdomain.ScopeReadOnly: readOnlyMethods,
+	apikeysdomain.ScopeWrite:    writeMethods,
+	apikeysdomain.ScopeAdmin:    adminMethods,
+}
+
+// authenticator resolves the caller from an x-api-key header, a JWT bearer
+// token or a verified client certificate, in that order.
+type authenticator struct {
+	jwt     *auth.JWTVerifier // nil cuando no hay claves JWT configuradas
+	apiKeys *apikeysapp.ApiKeyService
+}
+
+// authenticate validates the credentials in the incoming metadata and
+// returns a context carrying the resulting principal.
+func (a *authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		return a.authenticateApiKey(ctx, keys[0], fullMethod)
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		if principal, ok := certificatePrincipal(ctx); ok {
+			return auth.NewContext(ctx, principal), nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization must use the Bearer scheme")
+	}
+	if a.jwt == nil {
+		return nil, status.Error(codes.Unauthenticated, "bearer tokens are not enabled on this server")
+	}
+
+	principal, err := a.jwt.Verify(token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+func (a *authenticator) authenticateApiKey(ctx context.Context, secret, fullMethod string) (context.Context, error) {
+	key, principal, err := a.apiKeys.Authenticate(ctx, secret)
+	if err != nil {
+		switch {
+		case errors.Is(err, apikeysdomain.ErrInvalidKey):
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		case errors.Is(err, apikeysdomain.ErrPermissionDenied):
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to validate api key: %v", err)
+	}
+
+	allowed := false
+	for _, method := range apiKeyScopeMethods[key.Scope] {
+		if method == fullMethod {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "api key scope %q does not allow %s", key.Scope, fullMethod)
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+// certificatePrincipal extracts the caller identity from a verified client
+// certificate, if the connection presented one.
+func certificatePrincipal(ctx context.Context) (*auth.Principal, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	subject := tlsconfig.IdentityFromCertificate(tlsInfo.State.VerifiedChains[0][0])
+	if subject == "" {
+		return nil, false
+	}
+
+	return &auth.Principal{Subject: subject}, true
+}
+
+func (a *authenticator) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (a *authenticator) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublicMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// wrappedStream overrides the context of a grpc.ServerStream.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
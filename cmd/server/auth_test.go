@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	apikeysapp "github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/application"
+	apikeysdomain "github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+func TestAuthenticateBearer(t *testing.T) {
+	verifier, err := auth.NewJWTVerifier(auth.JWTConfig{Secret: testJWTSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "alice",
+		"roles": []string{auth.RoleViewer},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		jwt      *auth.JWTVerifier
+		md       metadata.MD
+		wantCode codes.Code
+	}{
+		{"valid token", verifier, metadata.Pairs("authorization", "Bearer "+token), codes.OK},
+		{"scheme is case insensitive", verifier, metadata.Pairs("authorization", "bearer "+token), codes.OK},
+		{"missing metadata", verifier, nil, codes.Unauthenticated},
+		{"basic scheme", verifier, metadata.Pairs("authorization", "Basic YWxpY2U6cHc="), codes.Unauthenticated},
+		{"empty token", verifier, metadata.Pairs("authorization", "Bearer "), codes.Unauthenticated},
+		{"invalid token", verifier, metadata.Pairs("authorization", "Bearer "+token+"x"), codes.Unauthenticated},
+		{"bearer disabled", nil, metadata.Pairs("authorization", "Bearer "+token), codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authn := &authenticator{jwt: tt.jwt}
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			ctx, err := authn.authenticate(ctx, taskpb.TaskService_GetTask_FullMethodName)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("authenticate error = %v, want code %s", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			principal, ok := auth.FromContext(ctx)
+			if !ok || principal.Subject != "alice" || !slices.Equal(principal.Roles, []string{auth.RoleViewer}) {
+				t.Errorf("principal = %+v, want alice with roles [viewer]", principal)
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptorSkipsPublicMethods(t *testing.T) {
+	interceptor := (&authenticator{}).unaryInterceptor()
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler); err != nil {
+		t.Errorf("health check error = %v, want nil", err)
+	}
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: taskpb.TaskService_GetTask_FullMethodName}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("GetTask error = %v, want Unauthenticated", err)
+	}
+}
+
+// memoryApiKeyRepo keeps the keys in memory, indexed by hash.
+type memoryApiKeyRepo struct {
+	apikeysdomain.ApiKeyRepository
+	keys map[string]*apikeysdomain.ApiKey
+}
+
+func (r *memoryApiKeyRepo) CreateApiKey(_ context.Context, key *apikeysdomain.ApiKey) (*apikeysdomain.ApiKey, error) {
+	r.keys[key.KeyHash] = key
+	return key, nil
+}
+
+func (r *memoryApiKeyRepo) GetApiKeyByHash(_ context.Context, keyHash string) (*apikeysdomain.ApiKey, error) {
+	key, ok := r.keys[keyHash]
+	if !ok {
+		return nil, apikeysdomain.ErrInvalidKey
+	}
+	return key, nil
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	service := apikeysapp.NewApiKeyService(&memoryApiKeyRepo{keys: map[string]*apikeysdomain.ApiKey{}}, auth.DefaultPolicy())
+	newKey := func(scope apikeysdomain.Scope, roles ...string) string {
+		ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "alice", Roles: roles})
+		_, secret, err := service.CreateApiKey(ctx, "test", scope)
+		if err != nil {
+			t.Fatalf("CreateApiKey(%s, %v) error = %v", scope, roles, err)
+		}
+		return secret
+	}
+	adminKey := newKey(apikeysdomain.ScopeAdmin, auth.RoleAdmin)
+	adminWriteKey := newKey(apikeysdomain.ScopeWrite, auth.RoleAdmin)
+	viewerKey := newKey(apikeysdomain.ScopeReadOnly, auth.RoleViewer)
+
+	tests := []struct {
+		name      string
+		key       string
+		method    string
+		wantCode  codes.Code
+		wantRoles []string
+	}{
+		{"admin key lists all tasks", adminKey, taskpb.TaskService_ListAllTasks_FullMethodName, codes.OK, []string{auth.RoleAdmin}},
+		{"admin key cannot manage keys", adminKey, taskpb.ApiKeyService_CreateApiKey_FullMethodName, codes.PermissionDenied, nil},
+		{"write key of an admin is not admin", adminWriteKey, taskpb.TaskService_DeleteTask_FullMethodName, codes.OK, []string{}},
+		{"write key cannot list all tasks", adminWriteKey, taskpb.TaskService_ListAllTasks_FullMethodName, codes.PermissionDenied, nil},
+		{"viewer key reads", viewerKey, taskpb.TaskService_GetTask_FullMethodName, codes.OK, []string{auth.RoleViewer}},
+		{"viewer key cannot write", viewerKey, taskpb.TaskService_CreateTask_FullMethodName, codes.PermissionDenied, nil},
+		{"unknown key", "tmk_unknown", taskpb.TaskService_GetTask_FullMethodName, codes.Unauthenticated, nil},
+	}
+
+	authn := &authenticator{apiKeys: service}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", tt.key))
+
+			ctx, err := authn.authenticate(ctx, tt.method)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("authenticate error = %v, want code %s", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			principal, ok := auth.FromContext(ctx)
+			if !ok || principal.Subject != "alice" || !slices.Equal(principal.Roles, tt.wantRoles) {
+				t.Errorf("principal = %+v, want alice with roles %v", principal, tt.wantRoles)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// methodTimeouts holds the default deadline applied when the client sends
+// none, and the maximum deadline a client may request.
+type methodTimeouts struct {
+	Default time.Duration
+	Max     time.Duration
+}
+
+// deadlinePolicy resolves the timeouts of each RPC. Methods can be
+// overridden by their full name or by their short name (e.g. "ListAllTasks").
+type deadlinePolicy struct {
+	fallback methodTimeouts
+	methods  map[string]methodTimeouts
+}
+
+// parseMethodTimeouts parses overrides with the form
+// "ListAllTasks=20s:60s,GetTask=2s:5s" (default:max).
+func parseMethodTimeouts(value string) (map[string]methodTimeouts, error) {
+	methods := make(map[string]methodTimeouts)
+	if value == "" {
+		return methods, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, durations, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid method timeout %q", entry)
+		}
+
+		defaultStr, maxStr, found := strings.Cut(durations, ":")
+		if !found {
+			maxStr = defaultStr
+		}
+
+		defaultTimeout, err := time.ParseDuration(defaultStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default timeout for %s: %w", name, err)
+		}
+		maxTimeout, err := time.ParseDuration(maxStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max timeout for %s: %w", name, err)
+		}
+		if defaultTimeout > maxTimeout {
+			return nil, fmt.Errorf("default timeout for %s exceeds its max", name)
+		}
+
+		methods[name] = methodTimeouts{Default: defaultTimeout, Max: maxTimeout}
+	}
+
+	return methods, nil
+}
+
+func (p *deadlinePolicy) forMethod(fullMethod string) methodTimeouts {
+	if t, ok := p.methods[fullMethod]; ok {
+		return t
+	}
+	if t, ok := p.methods[path.Base(fullMethod)]; ok {
+		return t
+	}
+	return p.fallback
+}
+
+// apply sets the default deadline when the client sent none and caps
+// deadlines longer than the maximum.
+func (p *deadlinePolicy) apply(ctx context.Context, fullMethod string) (context.Context, context.CancelFunc) {
+	timeouts := p.forMethod(fullMethod)
+
+	deadline, ok := ctx.Deadline()
+	switch {
+	case !ok && timeouts.Default > 0:
+		return context.WithTimeout(ctx, timeouts.Default)
+	case ok && timeouts.Max > 0 && time.Until(deadline) > timeouts.Max:
+		return context.WithTimeout(ctx, timeouts.Max)
+	}
+	return ctx, func() {}
+}
+
+func (p *deadlinePolicy) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := p.apply(ctx, info.FullMethod)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+func (p *deadlinePolicy) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := p.apply(ss.Context(), info.FullMethod)
+		defer cancel()
+
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
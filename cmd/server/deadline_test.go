@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestParseMethodTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]methodTimeouts
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string]methodTimeouts{}},
+		{
+			name:  "default and max",
+			value: "ListAllTasks=20s:60s, GetTask=2s:5s",
+			want: map[string]methodTimeouts{
+				"ListAllTasks": {Default: 20 * time.Second, Max: time.Minute},
+				"GetTask":      {Default: 2 * time.Second, Max: 5 * time.Second},
+			},
+		},
+		{
+			name:  "max defaults to the default",
+			value: "/task.TaskService/ExportTasks=5m",
+			want:  map[string]methodTimeouts{"/task.TaskService/ExportTasks": {Default: 5 * time.Minute, Max: 5 * time.Minute}},
+		},
+		{name: "missing equals", value: "GetTask:2s:5s", wantErr: true},
+		{name: "missing name", value: "=2s:5s", wantErr: true},
+		{name: "invalid default", value: "GetTask=two:5s", wantErr: true},
+		{name: "invalid max", value: "GetTask=2s:", wantErr: true},
+		{name: "default above max", value: "GetTask=10s:5s", wantErr: true},
+		{name: "trailing comma", value: "GetTask=2s:5s,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMethodTimeouts(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseMethodTimeouts(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMethodTimeouts(%q) error = %v", tt.value, err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("parseMethodTimeouts(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeadlinePolicyApply(t *testing.T) {
+	policy := &deadlinePolicy{
+		fallback: methodTimeouts{Default: 10 * time.Second, Max: 30 * time.Second},
+		methods: map[string]methodTimeouts{
+			"ListAllTasks":                  {Default: 20 * time.Second, Max: time.Minute},
+			"/task.TaskService/ExportTasks": {},
+		},
+	}
+	const getTask = "/task.TaskService/GetTask"
+
+	tests := []struct {
+		name   string
+		method string
+		client time.Duration // 0 sin deadline del cliente
+		want   time.Duration // 0 sin deadline
+	}{
+		{"default when the client sends none", getTask, 0, 10 * time.Second},
+		{"client deadline within max", getTask, 20 * time.Second, 20 * time.Second},
+		{"client deadline above max is capped", getTask, time.Hour, 30 * time.Second},
+		{"short name override", "/task.TaskService/ListAllTasks", 0, 20 * time.Second},
+		{"short name override cap", "/task.TaskService/ListAllTasks", time.Hour, time.Minute},
+		{"full name override without limits", "/task.TaskService/ExportTasks", 0, 0},
+		{"override without max keeps client deadline", "/task.TaskService/ExportTasks", time.Hour, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.client > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.client)
+				defer cancel()
+			}
+
+			ctx, cancel := policy.apply(ctx, tt.method)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if tt.want == 0 {
+				if ok {
+					t.Errorf("deadline in %s, want none", time.Until(deadline))
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("no deadline, want %s", tt.want)
+			}
+			if remaining := time.Until(deadline); remaining > tt.want || remaining < tt.want-time.Second {
+				t.Errorf("deadline in %s, want %s", remaining, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeadlineInterceptors(t *testing.T) {
+	policy := &deadlinePolicy{fallback: methodTimeouts{Default: time.Second, Max: time.Second}}
+	info := &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/GetTask"}
+
+	var handlerCtx context.Context
+	_, err := policy.unaryInterceptor()(context.Background(), nil, info, func(ctx context.Context, _ any) (any, error) {
+		handlerCtx = ctx
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := handlerCtx.Deadline(); !ok {
+		t.Error("unary handler ran without a deadline")
+	}
+	// El contexto se cancela al terminar la llamada
+	if handlerCtx.Err() == nil {
+		t.Error("unary context was not cancelled after the call")
+	}
+
+	err = policy.streamInterceptor()(nil, &wrappedStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/task.TaskService/ExportTasks"},
+		func(_ any, ss grpc.ServerStream) error {
+			handlerCtx = ss.Context()
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := handlerCtx.Deadline(); !ok {
+		t.Error("stream handler ran without a deadline")
+	}
+}
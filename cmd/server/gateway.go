@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// The REST gateway talks to a second gRPC server over an in-memory listener
+// so that its calls go through the same interceptors as any other client
+// without needing a TLS client certificate for the public port.
+const gatewayBufferSize = 1 << 20
+
+func dialGateway(lis *bufconn.Listener) (*grpc.ClientConn, error) {
+	return grpc.NewClient("passthrough:///gateway",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+}
+
+// forwardedPeer replaces the in-memory peer of a gateway call with the HTTP
+// client address, taken from the last x-forwarded-for entry added by the
+// gateway, so logs and IP rate limits see the real caller.
+func forwardedPeer(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("x-forwarded-for")
+	if len(values) == 0 {
+		return ctx
+	}
+
+	hops := strings.Split(values[len(values)-1], ",")
+	ip := net.ParseIP(strings.TrimSpace(hops[len(hops)-1]))
+	if ip == nil {
+		return ctx
+	}
+
+	p, _ := peer.FromContext(ctx)
+	forwarded := &peer.Peer{Addr: &net.TCPAddr{IP: ip}}
+	if p != nil {
+		forwarded.LocalAddr = p.LocalAddr
+		forwarded.AuthInfo = p.AuthInfo
+	}
+	return peer.NewContext(ctx, forwarded)
+}
+
+func forwardedPeerUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(forwardedPeer(ctx), req)
+	}
+}
+
+func forwardedPeerStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: forwardedPeer(ss.Context())})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthServices returns the services registered on server whose status is
+// reported individually, after the empty name of the overall server status.
+// Public services such as health and reflection are left out.
+func healthServices(server *grpc.Server) []string {
+	services := []string{""}
+	for name := range server.GetServiceInfo() {
+		if !isPublicMethod("/" + name + "/") {
+			services = append(services, name)
+		}
+	}
+	slices.Sort(services)
+	return services
+}
+
+func setServingStatus(healthServer *health.Server, services []string, status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range services {
+		healthServer.SetServingStatus(service, status)
+	}
+}
+
+// watchDatabaseHealth pings the database every interval and reports
+// NOT_SERVING while it is unreachable.
+func watchDatabaseHealth(ctx context.Context, healthServer *health.Server, services []string, dbPool *pgxpool.Pool, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	serving := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		err := dbPool.Ping(pingCtx)
+		cancel()
+
+		// El contexto se cancela durante el apagado; no cambiar el estado
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case err != nil && serving:
+			slog.Warn("Database health check failed, reporting NOT_SERVING", "error", err)
+			setServingStatus(healthServer, services, healthpb.HealthCheckResponse_NOT_SERVING)
+			serving = false
+		case err == nil && !serving:
+			slog.Info("Database reachable again, reporting SERVING")
+			setServingStatus(healthServer, services, healthpb.HealthCheckResponse_SERVING)
+			serving = true
+		}
+	}
+}
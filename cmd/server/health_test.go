@@ -0,0 +1,31 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func TestHealthServicesFollowRegisteredServices(t *testing.T) {
+	server := grpc.NewServer()
+	taskpb.RegisterTaskServiceServer(server, taskpb.UnimplementedTaskServiceServer{})
+	taskpb.RegisterApiKeyServiceServer(server, taskpb.UnimplementedApiKeyServiceServer{})
+	taskpb.RegisterCalendarServiceServer(server, taskpb.UnimplementedCalendarServiceServer{})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+
+	want := []string{
+		"",
+		taskpb.ApiKeyService_ServiceDesc.ServiceName,
+		taskpb.CalendarService_ServiceDesc.ServiceName,
+		taskpb.TaskService_ServiceDesc.ServiceName,
+	}
+	if got := healthServices(server); !slices.Equal(got, want) {
+		t.Errorf("healthServices = %q, want %q", got, want)
+	}
+}
@@ -2,83 +2,290 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
+	"maps"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	apikeysapp "github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/application"
+	apikeysinfra "github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/infrastructure"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	calendarapp "github.com/Mayer-04/grpc-task-manager-go/internal/calendar/application"
+	calendarinfra "github.com/Mayer-04/grpc-task-manager-go/internal/calendar/infrastructure"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/config"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/database"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/gateway"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/logging"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/metrics"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/ratelimit"
 	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/application"
 	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/infrastructure"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tlsconfig"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tracing"
 	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 func main() {
 	// Cargar variables de entorno
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: .env file not found: %v", err)
-	}
+	envErr := godotenv.Load()
 
-	// Configuración del puerto
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "50051"
+	// "server config print" muestra la configuración efectiva sin arrancar
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "config" && args[1] == "print" {
+		cfg := loadConfig("server config print", args[2:])
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			fatal("Failed to print configuration", err)
+		}
+		return
 	}
 
-	// Configuración de la base de datos
-	dbUser := os.Getenv("POSTGRES_USER")
-	dbPassword := os.Getenv("POSTGRES_PASSWORD")
-	dbHost := os.Getenv("POSTGRES_HOST")
-	dbPort := os.Getenv("POSTGRES_PORT")
-	dbName := os.Getenv("POSTGRES_DB")
+	cfg := loadConfig("server", args)
 
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	if dbPort == "" {
-		dbPort = "5432"
+	// Configurar logging estructurado
+	logger, err := logging.New(os.Stderr, cfg.Log.Format, cfg.Log.Level)
+	if err != nil {
+		fatal("Failed to configure logging", err)
 	}
-	if dbName == "" {
-		dbName = "taskdb"
+	slog.SetDefault(logger)
+
+	if envErr != nil {
+		slog.Warn(".env file not found", "error", envErr)
 	}
 
-	// Crear connection string para PostgreSQL
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
+	// Configurar tracing distribuido
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: "task-manager-server",
+		Exporter:    cfg.Tracing.Exporter,
+		File:        cfg.Tracing.File,
+	})
+	if err != nil {
+		fatal("Failed to configure tracing", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
 
 	// Conectar a PostgreSQL
-	dbPool, err := pgxpool.New(context.Background(), connStr)
+	poolConfig, err := cfg.Database.PoolConfig()
+	if err != nil {
+		fatal("Invalid database configuration", err)
+	}
+	poolConfig.ConnConfig.Tracer = tracing.NewPgxTracer()
+
+	// Reintentar con backoff mientras PostgreSQL arranca
+	dbPool, err := database.Connect(context.Background(), poolConfig, cfg.Database.StartupTimeout)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("Failed to connect to database", err)
 	}
 	defer dbPool.Close()
+	slog.Info("Successfully connected to PostgreSQL", "max_conns", poolConfig.MaxConns)
+
+	// Los repositorios reintentan las sentencias que fallan por errores transitorios
+	db := database.NewRetryingPool(dbPool, cfg.Database.RetryPolicy())
 
-	// Verificar conexión
-	if err := dbPool.Ping(context.Background()); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+	// Cargar políticas de autorización
+	policy := auth.DefaultPolicy()
+	if policyFile := cfg.Auth.PolicyFile; policyFile != "" {
+		policy, err = auth.LoadPolicy(policyFile)
+		if err != nil {
+			fatal("Failed to load authorization policy", err)
+		}
+		slog.Info("Loaded authorization policy", "file", policyFile)
 	}
-	log.Println("Successfully connected to PostgreSQL")
 
 	// Inicializar capas
-	taskRepo := infrastructure.NewTaskRepository(dbPool)
-	taskService := application.NewTaskService(taskRepo)
+	taskRepo := infrastructure.NewTaskRepository(db)
+	taskService := application.NewTaskService(taskRepo, policy)
 	taskHandler := infrastructure.NewTaskHandler(taskService)
 
+	apiKeyRepo := apikeysinfra.NewApiKeyRepository(db)
+	apiKeyService := apikeysapp.NewApiKeyService(apiKeyRepo, policy)
+	apiKeyHandler := apikeysinfra.NewApiKeyHandler(apiKeyService)
+
+	feedRepo := calendarinfra.NewFeedRepository(db)
+	calendarService := calendarapp.NewCalendarService(feedRepo, taskService)
+	calendarHandler := calendarinfra.NewCalendarHandler(calendarService)
+
 	// Configurar servidor gRPC
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Server.Port))
+	if err != nil {
+		fatal("Failed to listen", err, "port", cfg.Server.Port)
+	}
+
+	// Contexto para las tareas en segundo plano del servidor
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Métricas de Prometheus (RPCs, pool de conexiones y tareas)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewPoolCollector(dbPool),
+		metrics.NewTaskCollector(taskRepo),
+	)
+	rpcMetrics := metrics.NewRPCMetrics(registry)
+
+	// Deadlines por defecto y máximos de cada RPC
+	deadlines := &deadlinePolicy{
+		fallback: methodTimeouts{Default: cfg.RPC.DefaultTimeout, Max: cfg.RPC.MaxTimeout},
+	}
+	if deadlines.methods, err = parseMethodTimeouts(config.StreamMethodTimeouts); err != nil {
+		fatal("Invalid built-in method timeouts", err)
+	}
+	overrides, err := parseMethodTimeouts(cfg.RPC.MethodTimeouts)
 	if err != nil {
-		log.Fatalf("Failed to listen on port %s: %v", port, err)
+		fatal("Invalid rpc.method_timeouts", err)
+	}
+	maps.Copy(deadlines.methods, overrides)
+
+	// Las descripciones de las tareas se ocultan en los logs salvo que se indique lo contrario
+	requestLogger := logging.NewInterceptor(logger, cfg.Log.RedactDescriptions)
+
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			requestLogger.UnaryServerInterceptor(),
+			rpcMetrics.UnaryServerInterceptor(),
+			recoveryUnaryInterceptor(),
+			deadlines.unaryInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			requestLogger.StreamServerInterceptor(),
+			rpcMetrics.StreamServerInterceptor(),
+			recoveryStreamInterceptor(),
+			deadlines.streamInterceptor(),
+		),
+	}
+
+	// Configurar TLS y verificación opcional de certificados de cliente
+	var transportOpts []grpc.ServerOption
+	var httpTLS *tls.Config
+	if cfg.TLS.Enabled() {
+		reloader, err := tlsconfig.NewReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+		if err != nil {
+			fatal("Failed to load TLS certificates", err)
+		}
+
+		tlsCfg, err := tlsconfig.ServerConfig(reloader, cfg.TLS.ClientAuth)
+		if err != nil {
+			fatal("Failed to configure TLS", err)
+		}
+
+		go reloader.Watch(ctx, cfg.TLS.ReloadInterval)
+
+		transportOpts = append(transportOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+
+		// El gateway REST usa el mismo certificado; la identidad de cliente solo se verifica en gRPC
+		httpTLS, err = tlsconfig.ServerConfig(reloader, tlsconfig.ClientAuthNone)
+		if err != nil {
+			fatal("Failed to configure TLS", err)
+		}
+		slog.Info("TLS enabled", "client_auth", cfg.TLS.ClientAuth)
+	}
+
+	// Rate limiting por IP y método, antes y después de autenticar
+	var rateLimiter *ratelimit.Interceptor
+	if cfg.RateLimit.Enabled {
+		limits, err := ratelimit.ParseLimits(cfg.RateLimit.Limits)
+		if err != nil {
+			fatal("Invalid rate_limit.limits", err)
+		}
+
+		var limiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+		if cfg.RateLimit.Backend == "postgres" {
+			postgresLimiter := ratelimit.NewPostgresLimiter(dbPool)
+			go postgresLimiter.Sweep(ctx, time.Minute)
+			limiter = postgresLimiter
+		}
+		rateLimiter = ratelimit.NewInterceptor(limiter, limits, cfg.RateLimit.FailOpen)
+
+		// Un solo bucket por IP para todos los métodos, que limita también las credenciales inválidas
+		if cfg.RateLimit.IPLimit != "" {
+			ipLimit, err := ratelimit.ParseLimit(cfg.RateLimit.IPLimit)
+			if err != nil {
+				fatal("Invalid rate_limit.ip_limit", err)
+			}
+			peerLimiter := ratelimit.NewPeerInterceptor(limiter, ipLimit, cfg.RateLimit.FailOpen)
+			serverOpts = append(serverOpts,
+				grpc.ChainUnaryInterceptor(peerLimiter.UnaryServerInterceptor()),
+				grpc.ChainStreamInterceptor(peerLimiter.StreamServerInterceptor()),
+			)
+		}
+	}
+
+	// Configurar autenticación (JWT, API keys y certificados de cliente)
+	if cfg.Auth.Disabled {
+		slog.Warn("Authentication is disabled, every caller has full access")
+	} else {
+		authn := &authenticator{apiKeys: apiKeyService}
+
+		if cfg.Auth.JWTEnabled() {
+			authn.jwt, err = auth.NewJWTVerifier(auth.JWTConfig{
+				Secret:   cfg.Auth.JWTSecret,
+				JWKSFile: cfg.Auth.JWKSFile,
+				Issuer:   cfg.Auth.JWTIssuer,
+				Audience: cfg.Auth.JWTAudience,
+			})
+			if err != nil {
+				fatal("Failed to configure authentication", err)
+			}
+		}
+
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(authn.unaryInterceptor()),
+			grpc.ChainStreamInterceptor(authn.streamInterceptor()),
+		)
 	}
 
-	grpcServer := grpc.NewServer()
+	// Rate limiting por usuario y método (después de autenticar)
+	if rateLimiter != nil {
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(rateLimiter.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(rateLimiter.StreamServerInterceptor()),
+		)
+	}
+
+	grpcServer := grpc.NewServer(append(serverOpts, transportOpts...)...)
 
-	// Registrar servicios
-	taskpb.RegisterTaskServiceServer(grpcServer, taskHandler)
+	// Servidor interno para el gateway REST, con los mismos interceptores pero sin TLS
+	gatewayBackend := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(forwardedPeerUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(forwardedPeerStreamInterceptor()),
+	}, serverOpts...)...)
+
+	// Registrar servicios y health checks ligados al estado de la base de datos
+	healthServer := health.NewServer()
+	for _, server := range []*grpc.Server{grpcServer, gatewayBackend} {
+		taskpb.RegisterTaskServiceServer(server, taskHandler)
+		taskpb.RegisterApiKeyServiceServer(server, apiKeyHandler)
+		taskpb.RegisterCalendarServiceServer(server, calendarHandler)
+		healthpb.RegisterHealthServer(server, healthServer)
+	}
+	services := healthServices(grpcServer)
+	setServingStatus(healthServer, services, healthpb.HealthCheckResponse_SERVING)
+
+	go watchDatabaseHealth(ctx, healthServer, services, dbPool, cfg.Server.HealthCheckInterval)
 
 	// Habilitar reflection para herramientas como grpcui
 	reflection.Register(grpcServer)
@@ -89,17 +296,115 @@ func main() {
 
 	// Iniciar servidor en una goroutine
 	go func() {
-		log.Printf("gRPC server starting on port %s", port)
+		slog.Info("gRPC server starting", "port", cfg.Server.Port)
 		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve gRPC server: %v", err)
+			fatal("Failed to serve gRPC server", err)
+		}
+	}()
+
+	// API REST/JSON, gRPC-Web y Connect en un segundo puerto
+	gatewayListener := bufconn.Listen(gatewayBufferSize)
+	go func() {
+		if err := gatewayBackend.Serve(gatewayListener); err != nil {
+			fatal("Failed to serve gateway backend", err)
+		}
+	}()
+
+	gatewayConn, err := dialGateway(gatewayListener)
+	if err != nil {
+		fatal("Failed to connect gateway to gRPC server", err)
+	}
+	defer gatewayConn.Close()
+
+	corsCfg := gateway.CORSConfig{
+		AllowedOrigins: cfg.CORS.AllowedOrigins,
+		AllowedMethods: cfg.CORS.AllowedMethods,
+		AllowedHeaders: cfg.CORS.AllowedHeaders,
+		MaxAge:         cfg.CORS.MaxAge,
+	}
+
+	httpServer, err := gateway.NewServer(ctx, fmt.Sprintf(":%s", cfg.Server.HTTPPort), gatewayConn, corsCfg)
+	if err != nil {
+		fatal("Failed to configure REST gateway", err)
+	}
+
+	// Los feeds ICS se autentican con el token de su URL, sin pasar por el gateway
+	routes := http.NewServeMux()
+	routes.Handle(calendarinfra.FeedPattern, calendarinfra.NewFeedHandler(calendarService, cfg.RPC.DefaultTimeout))
+	routes.Handle("/", httpServer.Handler)
+	httpServer.Handler = routes
+	// HTTP/2 para gRPC y streaming: ALPN con TLS, o h2c en desarrollo local
+	if httpTLS != nil {
+		httpTLS.NextProtos = []string{"h2", "http/1.1"}
+	} else if cfg.Server.H2C {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		protocols.SetUnencryptedHTTP2(true)
+		httpServer.Protocols = protocols
+	}
+	httpServer.TLSConfig = httpTLS
+	go func() {
+		slog.Info("REST gateway starting", "port", cfg.Server.HTTPPort, "tls", httpTLS != nil)
+		serve := httpServer.ListenAndServe
+		if httpTLS != nil {
+			serve = func() error { return httpServer.ListenAndServeTLS("", "") }
+		}
+		if err := serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("Failed to serve REST gateway", err)
+		}
+	}()
+
+	// Servir métricas en un puerto HTTP separado
+	metricsServer := metrics.NewServer(fmt.Sprintf(":%s", cfg.Server.MetricsPort), registry)
+	go func() {
+		slog.Info("Metrics server starting", "port", cfg.Server.MetricsPort)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("Failed to serve metrics", err)
 		}
 	}()
 
 	// Esperar señal de shutdown
 	<-quit
-	log.Println("Shutting down gRPC server...")
+	slog.Info("Shutting down gRPC server...")
+
+	// Dejar de recibir tráfico nuevo mientras se drenan las conexiones
+	cancel()
+	healthServer.Shutdown()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to stop REST gateway", "error", err)
+	}
 
 	// Graceful shutdown
+	gatewayBackend.GracefulStop()
 	grpcServer.GracefulStop()
-	log.Println("gRPC server stopped")
+	slog.Info("gRPC server stopped")
+
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to stop metrics server", "error", err)
+	}
+}
+
+// loadConfig layers the config file, environment and flags, exiting on
+// invalid configuration.
+func loadConfig(name string, args []string) *config.Config {
+	cfg, err := config.Load(name, args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fatal("Failed to load configuration", err)
+	}
+	return cfg
+}
+
+// fatal logs an error and exits the process.
+func fatal(msg string, err error, args ...any) {
+	if err != nil {
+		args = append(args, "error", err)
+	}
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recoverPanic converts a panic into an Internal error and logs its stack.
+func recoverPanic(ctx context.Context, method string, err *error) {
+	if r := recover(); r != nil {
+		logging.FromContext(ctx).Error("panic recovered",
+			"method", method,
+			"panic", r,
+			"stack", string(debug.Stack()),
+		)
+		*err = status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func recoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer recoverPanic(ctx, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+func recoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverPanic(ss.Context(), info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	interceptor := recoveryUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/GetTask"}
+
+	tests := []struct {
+		name     string
+		handler  grpc.UnaryHandler
+		wantCode codes.Code
+	}{
+		{"panic", func(context.Context, any) (any, error) { panic("boom") }, codes.Internal},
+		{"panic with error", func(context.Context, any) (any, error) { panic(errors.New("boom")) }, codes.Internal},
+		{"nil dereference", func(context.Context, any) (any, error) {
+			var task *struct{ Title string }
+			return task.Title, nil
+		}, codes.Internal},
+		{"error is kept", func(context.Context, any) (any, error) { return nil, status.Error(codes.NotFound, "missing") }, codes.NotFound},
+		{"success", func(context.Context, any) (any, error) { return "ok", nil }, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(context.Background(), nil, info, tt.handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	interceptor := recoveryStreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/task.TaskService/ExportTasks"}
+	stream := &wrappedStream{ctx: context.Background()}
+
+	err := interceptor(nil, stream, info, func(any, grpc.ServerStream) error { panic("boom") })
+	if status.Code(err) != codes.Internal {
+		t.Errorf("panicking stream error = %v, want Internal", err)
+	}
+	// No se filtran detalles del pánico al cliente
+	if status.Convert(err).Message() != "internal server error" {
+		t.Errorf("message = %q, want the generic message", status.Convert(err).Message())
+	}
+
+	if err := interceptor(nil, stream, info, func(any, grpc.ServerStream) error { return nil }); err != nil {
+		t.Errorf("stream error = %v, want nil", err)
+	}
+}
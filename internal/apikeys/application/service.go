@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/gofrs/uuid"
+)
+
+// keyPrefix marks the secrets issued by this service.
+const keyPrefix = "tmk_"
+
+// scopeActions lists the task actions each scope needs. Read-only and write
+// keys act on the tasks of their owner; admin keys act on every task.
+var scopeActions = map[domain.Scope][]auth.Action{
+	domain.ScopeReadOnly: {auth.ActionRead, auth.ActionList},
+	domain.ScopeWrite: {
+		auth.ActionRead, auth.ActionList, auth.ActionCreate, auth.ActionUpdate,
+		auth.ActionDelete, auth.ActionComplete, auth.ActionShare,
+	},
+	domain.ScopeAdmin: {
+		auth.ActionRead, auth.ActionList, auth.ActionListAll, auth.ActionCreate,
+		auth.ActionUpdate, auth.ActionDelete, auth.ActionComplete, auth.ActionShare,
+	},
+}
+
+type ApiKeyService struct {
+	apiKeyRepo domain.ApiKeyRepository
+	policy     *auth.Policy
+}
+
+func NewApiKeyService(apiKeyRepo domain.ApiKeyRepository, policy *auth.Policy) *ApiKeyService {
+	return &ApiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		policy:     policy,
+	}
+}
+
+// CreateApiKey issues a new key for the caller and returns it together with
+// the plaintext secret, which is never stored.
+func (s *ApiKeyService) CreateApiKey(ctx context.Context, name string, scope domain.Scope) (*domain.ApiKey, string, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: api keys require an authenticated caller", domain.ErrPermissionDenied)
+	}
+	if name == "" {
+		return nil, "", fmt.Errorf("name is required")
+	}
+	if _, ok := scopeActions[scope]; !ok {
+		return nil, "", fmt.Errorf("invalid scope %q", scope)
+	}
+
+	// La clave guarda los roles del creador y nunca puede más que ellos
+	roles := keyRoles(scope, principal.Roles)
+	if !s.scopeAllowed(scope, principal.Subject, roles) {
+		return nil, "", fmt.Errorf("%w: your roles do not allow %s api keys", domain.ErrPermissionDenied, scope)
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	secret, prefix, err := generateSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key, err := s.apiKeyRepo.CreateApiKey(ctx, &domain.ApiKey{
+		ID:      id,
+		Name:    name,
+		OwnerID: principal.Subject,
+		Scope:   scope,
+		Roles:   roles,
+		Prefix:  prefix,
+		KeyHash: hashSecret(secret),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, secret, nil
+}
+
+func (s *ApiKeyService) ListApiKeys(ctx context.Context) ([]*domain.ApiKey, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: api keys require an authenticated caller", domain.ErrPermissionDenied)
+	}
+
+	return s.apiKeyRepo.ListApiKeysByOwner(ctx, principal.Subject)
+}
+
+// RotateApiKey replaces the secret of an active key, invalidating the old one.
+func (s *ApiKeyService) RotateApiKey(ctx context.Context, id string) (*domain.ApiKey, string, error) {
+	key, err := s.getOwnedKey(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if !key.Active() {
+		return nil, "", fmt.Errorf("cannot rotate a revoked api key")
+	}
+
+	secret, prefix, err := generateSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rotated, err := s.apiKeyRepo.RotateApiKey(ctx, id, prefix, hashSecret(secret))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return rotated, secret, nil
+}
+
+func (s *ApiKeyService) RevokeApiKey(ctx context.Context, id string) error {
+	if _, err := s.getOwnedKey(ctx, id); err != nil {
+		return err
+	}
+
+	return s.apiKeyRepo.RevokeApiKey(ctx, id)
+}
+
+// Authenticate resolves a plaintext secret to its active key and returns
+// the principal it acts as: its owner, limited to the roles stored on the
+// key. Keys whose scope those roles no longer cover under the current
+// policy are rejected with ErrPermissionDenied.
+func (s *ApiKeyService) Authenticate(ctx context.Context, secret string) (*domain.ApiKey, *auth.Principal, error) {
+	if !strings.HasPrefix(secret, keyPrefix) {
+		return nil, nil, domain.ErrInvalidKey
+	}
+
+	key, err := s.apiKeyRepo.GetApiKeyByHash(ctx, hashSecret(secret))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !key.Active() {
+		return nil, nil, domain.ErrInvalidKey
+	}
+
+	roles := keyRoles(key.Scope, key.Roles)
+	if !s.scopeAllowed(key.Scope, key.OwnerID, roles) {
+		return nil, nil, fmt.Errorf("%w: api key scope %q exceeds the roles of its owner", domain.ErrPermissionDenied, key.Scope)
+	}
+
+	return key, &auth.Principal{Subject: key.OwnerID, Roles: roles}, nil
+}
+
+func (s *ApiKeyService) getOwnedKey(ctx context.Context, id string) (*domain.ApiKey, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: api keys require an authenticated caller", domain.ErrPermissionDenied)
+	}
+	if id == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	// Validar que sea un UUID válido
+	if _, err := uuid.FromString(id); err != nil {
+		return nil, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	key, err := s.apiKeyRepo.GetApiKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.OwnerID != principal.Subject && !slices.Contains(principal.Roles, auth.RoleAdmin) {
+		return nil, fmt.Errorf("%w: api key belongs to another user", domain.ErrPermissionDenied)
+	}
+
+	return key, nil
+}
+
+// keyRoles returns the roles a key of scope acts with. Only admin keys keep
+// the admin role; without roles the policy's default role applies, as for
+// tokens.
+func keyRoles(scope domain.Scope, roles []string) []string {
+	result := []string{}
+	for _, role := range roles {
+		if role != auth.RoleAdmin || scope == domain.ScopeAdmin {
+			result = append(result, role)
+		}
+	}
+	return result
+}
+
+// scopeAllowed reports whether roles grant every action of scope: on the
+// tasks of ownerID, or on any task for admin keys.
+func (s *ApiKeyService) scopeAllowed(scope domain.Scope, ownerID string, roles []string) bool {
+	principal := &auth.Principal{Subject: ownerID, Roles: roles}
+	resourceOwner := ownerID
+	if scope == domain.ScopeAdmin {
+		resourceOwner = ""
+	}
+
+	for _, action := range scopeActions[scope] {
+		if !s.policy.Allowed(principal, action, resourceOwner) {
+			return false
+		}
+	}
+	return true
+}
+
+// generateSecret returns a new random secret and its displayable prefix.
+func generateSecret() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	secret := keyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return secret, secret[:len(keyPrefix)+8], nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,185 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+)
+
+// memoryRepo keeps the keys in memory, indexed by hash.
+type memoryRepo struct {
+	domain.ApiKeyRepository
+	keys map[string]*domain.ApiKey
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{keys: map[string]*domain.ApiKey{}}
+}
+
+func (r *memoryRepo) CreateApiKey(_ context.Context, key *domain.ApiKey) (*domain.ApiKey, error) {
+	stored := *key
+	r.keys[key.KeyHash] = &stored
+	return &stored, nil
+}
+
+func (r *memoryRepo) GetApiKeyByHash(_ context.Context, keyHash string) (*domain.ApiKey, error) {
+	key, ok := r.keys[keyHash]
+	if !ok {
+		return nil, domain.ErrInvalidKey
+	}
+	return key, nil
+}
+
+func callerContext(subject string, roles ...string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Subject: subject, Roles: roles})
+}
+
+func TestCreateApiKeyScopes(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []string
+		scope     domain.Scope
+		wantRoles []string
+		wantErr   error
+	}{
+		{"default role read-only", nil, domain.ScopeReadOnly, []string{}, nil},
+		{"default role write", nil, domain.ScopeWrite, []string{}, nil},
+		{"default role admin", nil, domain.ScopeAdmin, nil, domain.ErrPermissionDenied},
+		{"owner write", []string{auth.RoleOwner}, domain.ScopeWrite, []string{auth.RoleOwner}, nil},
+		{"viewer read-only", []string{auth.RoleViewer}, domain.ScopeReadOnly, []string{auth.RoleViewer}, nil},
+		{"viewer write", []string{auth.RoleViewer}, domain.ScopeWrite, nil, domain.ErrPermissionDenied},
+		{"viewer admin", []string{auth.RoleViewer}, domain.ScopeAdmin, nil, domain.ErrPermissionDenied},
+		{"admin admin", []string{auth.RoleAdmin}, domain.ScopeAdmin, []string{auth.RoleAdmin}, nil},
+		// Fuera del alcance admin la clave pierde el rol y aplica el rol por defecto
+		{"admin write", []string{auth.RoleAdmin}, domain.ScopeWrite, []string{}, nil},
+		{"admin and viewer write", []string{auth.RoleAdmin, auth.RoleViewer}, domain.ScopeWrite, nil, domain.ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewApiKeyService(newMemoryRepo(), auth.DefaultPolicy())
+
+			key, secret, err := service.CreateApiKey(callerContext("alice", tt.roles...), "ci", tt.scope)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateApiKey error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !slices.Equal(key.Roles, tt.wantRoles) || key.Roles == nil {
+				t.Errorf("key roles = %#v, want %#v", key.Roles, tt.wantRoles)
+			}
+
+			_, principal, err := service.Authenticate(context.Background(), secret)
+			if err != nil {
+				t.Fatalf("Authenticate error = %v", err)
+			}
+			if principal.Subject != "alice" || !slices.Equal(principal.Roles, tt.wantRoles) {
+				t.Errorf("principal = %+v, want alice with roles %v", principal, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestCreateApiKeyRequiresCaller(t *testing.T) {
+	service := NewApiKeyService(newMemoryRepo(), auth.DefaultPolicy())
+
+	if _, _, err := service.CreateApiKey(context.Background(), "ci", domain.ScopeReadOnly); !errors.Is(err, domain.ErrPermissionDenied) {
+		t.Errorf("CreateApiKey without caller error = %v, want %v", err, domain.ErrPermissionDenied)
+	}
+	if _, _, err := service.CreateApiKey(callerContext("alice"), "ci", "root"); err == nil {
+		t.Error("CreateApiKey accepted an unknown scope")
+	}
+}
+
+// TestApiKeyPrincipal checks what a key may do with the principal it
+// authenticates as.
+func TestApiKeyPrincipal(t *testing.T) {
+	policy := auth.DefaultPolicy()
+	tests := []struct {
+		name    string
+		roles   []string
+		scope   domain.Scope
+		action  auth.Action
+		ownerID string
+		want    bool
+	}{
+		{"viewer key cannot create", []string{auth.RoleViewer}, domain.ScopeReadOnly, auth.ActionCreate, "alice", false},
+		{"viewer key reads any task", []string{auth.RoleViewer}, domain.ScopeReadOnly, auth.ActionRead, "bob", true},
+		{"write key acts on own tasks", nil, domain.ScopeWrite, auth.ActionDelete, "alice", true},
+		{"write key of an admin is not admin", []string{auth.RoleAdmin}, domain.ScopeWrite, auth.ActionDelete, "bob", false},
+		{"admin key acts on any task", []string{auth.RoleAdmin}, domain.ScopeAdmin, auth.ActionDelete, "bob", true},
+		{"admin key lists all tasks", []string{auth.RoleAdmin}, domain.ScopeAdmin, auth.ActionListAll, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewApiKeyService(newMemoryRepo(), policy)
+			_, secret, err := service.CreateApiKey(callerContext("alice", tt.roles...), "ci", tt.scope)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, principal, err := service.Authenticate(context.Background(), secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := policy.Allowed(principal, tt.action, tt.ownerID); got != tt.want {
+				t.Errorf("Allowed(%+v, %s, %q) = %t, want %t", principal, tt.action, tt.ownerID, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAuthenticateRejectsUncoveredScope covers keys whose stored roles no
+// longer cover their scope, such as keys created before roles were stored
+// or under an older policy.
+func TestAuthenticateRejectsUncoveredScope(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope domain.Scope
+		roles []string
+	}{
+		{"admin key without admin role", domain.ScopeAdmin, []string{}},
+		{"write key of a viewer", domain.ScopeWrite, []string{auth.RoleViewer}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepo()
+			service := NewApiKeyService(repo, auth.DefaultPolicy())
+			secret := keyPrefix + "legacy"
+			repo.keys[hashSecret(secret)] = &domain.ApiKey{OwnerID: "alice", Scope: tt.scope, Roles: tt.roles}
+
+			if _, _, err := service.Authenticate(context.Background(), secret); !errors.Is(err, domain.ErrPermissionDenied) {
+				t.Errorf("Authenticate error = %v, want %v", err, domain.ErrPermissionDenied)
+			}
+		})
+	}
+}
+
+func TestAuthenticateInvalidKeys(t *testing.T) {
+	repo := newMemoryRepo()
+	service := NewApiKeyService(repo, auth.DefaultPolicy())
+	_, secret, err := service.CreateApiKey(callerContext("alice"), "ci", domain.ScopeReadOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, candidate := range []string{"", "not-a-key", secret + "x"} {
+		if _, _, err := service.Authenticate(context.Background(), candidate); !errors.Is(err, domain.ErrInvalidKey) {
+			t.Errorf("Authenticate(%q) error = %v, want %v", candidate, err, domain.ErrInvalidKey)
+		}
+	}
+
+	for _, key := range repo.keys {
+		key.RevokedAt = &key.CreatedAt
+	}
+	if _, _, err := service.Authenticate(context.Background(), secret); !errors.Is(err, domain.ErrInvalidKey) {
+		t.Errorf("Authenticate of a revoked key error = %v, want %v", err, domain.ErrInvalidKey)
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Scope limits which operations an API key can perform.
+type Scope string
+
+const (
+	ScopeReadOnly Scope = "read-only"
+	ScopeWrite    Scope = "write"
+	ScopeAdmin    Scope = "admin"
+)
+
+type ApiKey struct {
+	ID      uuid.UUID
+	Name    string
+	OwnerID string
+	Scope   Scope
+	// Roles are the roles of the owner when the key was created. A key never
+	// grants more than they allowed, whatever its scope.
+	Roles     []string
+	Prefix    string
+	KeyHash   string
+	CreatedAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+// Active reports whether the key can still be used.
+func (k *ApiKey) Active() bool {
+	return k.RevokedAt == nil
+}
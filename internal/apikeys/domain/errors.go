@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("api key not found")
+	ErrInvalidKey       = errors.New("invalid api key")
+	ErrPermissionDenied = errors.New("permission denied")
+)
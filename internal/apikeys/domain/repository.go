@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+type ApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, key *ApiKey) (*ApiKey, error)
+	GetApiKey(ctx context.Context, id string) (*ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (*ApiKey, error)
+	ListApiKeysByOwner(ctx context.Context, ownerID string) ([]*ApiKey, error)
+	RotateApiKey(ctx context.Context, id, prefix, keyHash string) (*ApiKey, error)
+	RevokeApiKey(ctx context.Context, id string) error
+}
@@ -0,0 +1,131 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/application"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ApiKeyHandler struct {
+	taskpb.UnimplementedApiKeyServiceServer
+	apiKeyService *application.ApiKeyService
+}
+
+func NewApiKeyHandler(apiKeyService *application.ApiKeyService) *ApiKeyHandler {
+	return &ApiKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (h *ApiKeyHandler) CreateApiKey(ctx context.Context, req *taskpb.CreateApiKeyRequest) (*taskpb.CreateApiKeyResponse, error) {
+	scope, ok := protoScopeToDomain[req.Scope]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid scope: %v", req.Scope)
+	}
+
+	key, secret, err := h.apiKeyService.CreateApiKey(ctx, req.Name, scope)
+	if err != nil {
+		return nil, toStatusError(err, "failed to create api key")
+	}
+
+	return &taskpb.CreateApiKeyResponse{
+		ApiKey: h.domainApiKeyToProto(key),
+		Secret: secret,
+	}, nil
+}
+
+func (h *ApiKeyHandler) ListApiKeys(ctx context.Context, req *taskpb.ListApiKeysRequest) (*taskpb.ListApiKeysResponse, error) {
+	keys, err := h.apiKeyService.ListApiKeys(ctx)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list api keys")
+	}
+
+	var protoKeys []*taskpb.ApiKey
+	for _, key := range keys {
+		protoKeys = append(protoKeys, h.domainApiKeyToProto(key))
+	}
+
+	return &taskpb.ListApiKeysResponse{
+		ApiKeys: protoKeys,
+	}, nil
+}
+
+func (h *ApiKeyHandler) RotateApiKey(ctx context.Context, req *taskpb.RotateApiKeyRequest) (*taskpb.RotateApiKeyResponse, error) {
+	key, secret, err := h.apiKeyService.RotateApiKey(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err, "failed to rotate api key")
+	}
+
+	return &taskpb.RotateApiKeyResponse{
+		ApiKey: h.domainApiKeyToProto(key),
+		Secret: secret,
+	}, nil
+}
+
+func (h *ApiKeyHandler) RevokeApiKey(ctx context.Context, req *taskpb.RevokeApiKeyRequest) (*taskpb.RevokeApiKeyResponse, error) {
+	err := h.apiKeyService.RevokeApiKey(ctx, req.Id)
+	if err != nil {
+		return &taskpb.RevokeApiKeyResponse{
+			Success: false,
+			Message: err.Error(),
+		}, toStatusError(err, "failed to revoke api key")
+	}
+
+	return &taskpb.RevokeApiKeyResponse{
+		Success: true,
+		Message: "API key revoked successfully",
+	}, nil
+}
+
+// toStatusError converts a service error into a gRPC status.
+func toStatusError(err error, msg string) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, domain.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, domain.ErrNotFound):
+		code = codes.NotFound
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
+
+var protoScopeToDomain = map[taskpb.ApiKeyScope]domain.Scope{
+	taskpb.ApiKeyScope_API_KEY_SCOPE_READ_ONLY: domain.ScopeReadOnly,
+	taskpb.ApiKeyScope_API_KEY_SCOPE_WRITE:     domain.ScopeWrite,
+	taskpb.ApiKeyScope_API_KEY_SCOPE_ADMIN:     domain.ScopeAdmin,
+}
+
+// domainApiKeyToProto converts a domain.ApiKey to a taskpb.ApiKey.
+func (h *ApiKeyHandler) domainApiKeyToProto(key *domain.ApiKey) *taskpb.ApiKey {
+	scope := taskpb.ApiKeyScope_API_KEY_SCOPE_UNSPECIFIED
+	for protoScope, domainScope := range protoScopeToDomain {
+		if domainScope == key.Scope {
+			scope = protoScope
+		}
+	}
+
+	return &taskpb.ApiKey{
+		Id:        key.ID.String(),
+		Name:      key.Name,
+		OwnerId:   key.OwnerID,
+		Scope:     scope,
+		Prefix:    key.Prefix,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+		RotatedAt: optionalTimestamp(key.RotatedAt),
+		RevokedAt: optionalTimestamp(key.RevokedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
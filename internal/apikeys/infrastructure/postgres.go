@@ -0,0 +1,140 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/apikeys/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/database"
+	"github.com/jackc/pgx/v5"
+)
+
+type ApiKeyRepositoryImpl struct {
+	dbpool database.Querier
+}
+
+func NewApiKeyRepository(dbPool database.Querier) domain.ApiKeyRepository {
+	return &ApiKeyRepositoryImpl{
+		dbpool: dbPool,
+	}
+}
+
+const apiKeyColumns = "id, name, owner_id, scope, roles, prefix, key_hash, created_at, rotated_at, revoked_at"
+
+func scanApiKey(row pgx.Row) (*domain.ApiKey, error) {
+	key := &domain.ApiKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.OwnerID,
+		&key.Scope,
+		&key.Roles,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.CreatedAt,
+		&key.RotatedAt,
+		&key.RevokedAt,
+	)
+	return key, err
+}
+
+func (r *ApiKeyRepositoryImpl) CreateApiKey(ctx context.Context, key *domain.ApiKey) (*domain.ApiKey, error) {
+	const query = `
+		INSERT INTO api_keys (id, name, owner_id, scope, roles, prefix, key_hash)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + apiKeyColumns + `;`
+
+	result, err := scanApiKey(r.dbpool.QueryRow(ctx, query, key.ID, key.Name, key.OwnerID, key.Scope, key.Roles, key.Prefix, key.KeyHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *ApiKeyRepositoryImpl) GetApiKey(ctx context.Context, id string) (*domain.ApiKey, error) {
+	const query = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1;`
+
+	key, err := scanApiKey(r.dbpool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *ApiKeyRepositoryImpl) GetApiKeyByHash(ctx context.Context, keyHash string) (*domain.ApiKey, error) {
+	const query = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1;`
+
+	key, err := scanApiKey(r.dbpool.QueryRow(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidKey
+		}
+		return nil, fmt.Errorf("failed to retrieve api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *ApiKeyRepositoryImpl) ListApiKeysByOwner(ctx context.Context, ownerID string) ([]*domain.ApiKey, error) {
+	const query = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE owner_id = $1 ORDER BY created_at;`
+
+	rows, err := r.dbpool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.ApiKey
+	for rows.Next() {
+		key, err := scanApiKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *ApiKeyRepositoryImpl) RotateApiKey(ctx context.Context, id, prefix, keyHash string) (*domain.ApiKey, error) {
+	const query = `
+		UPDATE api_keys
+		SET prefix = $1, key_hash = $2, rotated_at = NOW()
+		WHERE id = $3 AND revoked_at IS NULL
+		RETURNING ` + apiKeyColumns + `;`
+
+	key, err := scanApiKey(r.dbpool.QueryRow(ctx, query, prefix, keyHash, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *ApiKeyRepositoryImpl) RevokeApiKey(ctx context.Context, id string) error {
+	const query = "UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;"
+
+	result, err := r.dbpool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("could not revoke api key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
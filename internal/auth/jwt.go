@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTConfig holds the settings used to validate bearer tokens.
+type JWTConfig struct {
+	Secret   string // clave compartida para HS256
+	JWKSFile string // archivo JWKS local con claves RSA u "oct"
+	Issuer   string
+	Audience string
+}
+
+// JWTVerifier validates HS256/RS256 tokens and extracts the principal.
+type JWTVerifier struct {
+	hmacKey    []byte
+	hmacKeys   map[string][]byte
+	rsaKeys    map[string]*rsa.PublicKey
+	parserOpts []jwt.ParserOption
+}
+
+func NewJWTVerifier(cfg JWTConfig) (*JWTVerifier, error) {
+	v := &JWTVerifier{
+		hmacKeys: make(map[string][]byte),
+		rsaKeys:  make(map[string]*rsa.PublicKey),
+	}
+	if cfg.Secret != "" {
+		v.hmacKey = []byte(cfg.Secret)
+	}
+	if cfg.JWKSFile != "" {
+		if err := v.loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+	if v.hmacKey == nil && len(v.hmacKeys) == 0 && len(v.rsaKeys) == 0 {
+		return nil, errors.New("no JWT signing keys configured")
+	}
+
+	v.parserOpts = []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		v.parserOpts = append(v.parserOpts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		v.parserOpts = append(v.parserOpts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return v, nil
+}
+
+// Verify parses and validates a raw token, returning its principal.
+func (v *JWTVerifier) Verify(raw string) (*Principal, error) {
+	claims := &tokenClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, v.keyFunc, v.parserOpts...); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid token: missing sub claim")
+	}
+
+	return &Principal{Subject: claims.Subject, Roles: claims.Roles}, nil
+}
+
+func (v *JWTVerifier) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if key, ok := v.hmacKeys[kid]; ok {
+			return key, nil
+		}
+		if v.hmacKey != nil {
+			return v.hmacKey, nil
+		}
+	case jwt.SigningMethodRS256.Alg():
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// Sin "kid" solo se acepta si hay una única clave RSA
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no key found for alg %s and kid %q", token.Method.Alg(), kid)
+}
+
+// tokenClaims are the claims read from an access token.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		K   string `json:"k"`
+	} `json:"keys"`
+}
+
+func (v *JWTVerifier) loadJWKS(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to parse JWKS file: %w", err)
+	}
+
+	for _, key := range set.Keys {
+		switch key.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(key.N)
+			if err != nil {
+				return fmt.Errorf("invalid modulus for key %q: %w", key.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(key.E)
+			if err != nil {
+				return fmt.Errorf("invalid exponent for key %q: %w", key.Kid, err)
+			}
+			v.rsaKeys[key.Kid] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "oct":
+			k, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil {
+				return fmt.Errorf("invalid secret for key %q: %w", key.Kid, err)
+			}
+			v.hmacKeys[key.Kid] = k
+		default:
+			return fmt.Errorf("unsupported key type %q in JWKS", key.Kty)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// writeJWKS writes the public halves of keys, indexed by kid, to a JWKS file.
+func writeJWKS(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+	t.Helper()
+	type jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "RSA",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "alice",
+		"roles": []string{RoleViewer},
+		"iss":   "https://issuer.example.com",
+		"aud":   "task-manager",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims, key any) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func withClaim(name string, value any) jwt.MapClaims {
+	claims := validClaims()
+	if value == nil {
+		delete(claims, name)
+	} else {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestJWTVerifierHS256(t *testing.T) {
+	verifier, err := NewJWTVerifier(JWTConfig{Secret: testSecret, Issuer: "https://issuer.example.com", Audience: "task-manager"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := []byte(testSecret)
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"valid", sign(t, jwt.SigningMethodHS256, "", validClaims(), key), true},
+		{"wrong secret", sign(t, jwt.SigningMethodHS256, "", validClaims(), []byte("another-secret-another-secret-00")), false},
+		{"unsupported alg", sign(t, jwt.SigningMethodHS512, "", validClaims(), key), false},
+		{"alg none", sign(t, jwt.SigningMethodNone, "", validClaims(), jwt.UnsafeAllowNoneSignatureType), false},
+		{"missing exp", sign(t, jwt.SigningMethodHS256, "", withClaim("exp", nil), key), false},
+		{"expired", sign(t, jwt.SigningMethodHS256, "", withClaim("exp", time.Now().Add(-time.Minute).Unix()), key), false},
+		{"wrong issuer", sign(t, jwt.SigningMethodHS256, "", withClaim("iss", "https://evil.example.com"), key), false},
+		{"missing issuer", sign(t, jwt.SigningMethodHS256, "", withClaim("iss", nil), key), false},
+		{"wrong audience", sign(t, jwt.SigningMethodHS256, "", withClaim("aud", "another-service"), key), false},
+		{"audience in list", sign(t, jwt.SigningMethodHS256, "", withClaim("aud", []string{"another-service", "task-manager"}), key), true},
+		{"missing sub", sign(t, jwt.SigningMethodHS256, "", withClaim("sub", nil), key), false},
+		{"malformed", "not.a.token", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(tt.token)
+			if !tt.ok {
+				if err == nil {
+					t.Errorf("Verify accepted the token as %+v", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify error = %v", err)
+			}
+			if principal.Subject != "alice" || !slices.Equal(principal.Roles, []string{RoleViewer}) {
+				t.Errorf("principal = %+v, want alice with roles [viewer]", principal)
+			}
+		})
+	}
+}
+
+func TestJWTVerifierRS256KeySelection(t *testing.T) {
+	first, second := generateRSAKey(t), generateRSAKey(t)
+	verifier, err := NewJWTVerifier(JWTConfig{JWKSFile: writeJWKS(t, map[string]*rsa.PrivateKey{"first": first, "second": second})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicPEM, err := x509.MarshalPKIXPublicKey(&first.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicPEM})
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"first key", sign(t, jwt.SigningMethodRS256, "first", validClaims(), first), true},
+		{"second key", sign(t, jwt.SigningMethodRS256, "second", validClaims(), second), true},
+		{"kid of another key", sign(t, jwt.SigningMethodRS256, "first", validClaims(), second), false},
+		{"unknown kid", sign(t, jwt.SigningMethodRS256, "third", validClaims(), first), false},
+		// Con varias claves RSA el "kid" es obligatorio
+		{"no kid", sign(t, jwt.SigningMethodRS256, "", validClaims(), first), false},
+		// Un token HS256 firmado con la clave pública no debe validar como RSA
+		{"alg confusion with public key", sign(t, jwt.SigningMethodHS256, "first", validClaims(), publicPEM), false},
+		{"alg confusion with modulus", sign(t, jwt.SigningMethodHS256, "first", validClaims(), first.N.Bytes()), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(tt.token)
+			if tt.ok && err != nil {
+				t.Errorf("Verify error = %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Error("Verify accepted the token")
+			}
+		})
+	}
+}
+
+func TestJWTVerifierSingleRSAKeyWithoutKid(t *testing.T) {
+	key := generateRSAKey(t)
+	verifier, err := NewJWTVerifier(JWTConfig{JWKSFile: writeJWKS(t, map[string]*rsa.PrivateKey{"only": key})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "", validClaims(), key)); err != nil {
+		t.Errorf("Verify without kid error = %v", err)
+	}
+}
+
+func TestNewJWTVerifierErrors(t *testing.T) {
+	badJWKS := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(badJWKS, []byte(`{"keys":[{"kty":"EC","kid":"ec"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  JWTConfig
+	}{
+		{"no keys", JWTConfig{}},
+		{"missing jwks file", JWTConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")}},
+		{"unsupported key type", JWTConfig{JWKSFile: badJWKS}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewJWTVerifier(tt.cfg); err == nil {
+				t.Error("NewJWTVerifier error = nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Action identifies an operation subject to authorization.
+type Action string
+
+const (
+	ActionCreate   Action = "tasks.create"
+	ActionRead     Action = "tasks.read"
+	ActionUpdate   Action = "tasks.update"
+	ActionDelete   Action = "tasks.delete"
+	ActionComplete Action = "tasks.complete"
+	ActionList     Action = "tasks.list"
+	ActionListAll  Action = "tasks.list_all"
+	ActionShare    Action = "tasks.share"
+)
+
+// Scope defines which resources a role may act on.
+type Scope string
+
+const (
+	// ScopeOwn allows the action only on resources owned by the caller.
+	ScopeOwn Scope = "own"
+	// ScopeAny allows the action on every resource.
+	ScopeAny Scope = "any"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleViewer = "viewer"
+)
+
+// Policy maps roles to the actions they are allowed to perform.
+type Policy struct {
+	// DefaultRole is applied to principals whose token carries no roles.
+	DefaultRole string                      `json:"default_role"`
+	Roles       map[string]map[Action]Scope `json:"roles"`
+}
+
+// DefaultPolicy returns the built-in role definitions: owners manage their
+// own tasks, viewers can read everything and admins can do anything.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		DefaultRole: RoleOwner,
+		Roles: map[string]map[Action]Scope{
+			RoleOwner: {
+				ActionCreate:   ScopeOwn,
+				ActionRead:     ScopeOwn,
+				ActionUpdate:   ScopeOwn,
+				ActionDelete:   ScopeOwn,
+				ActionComplete: ScopeOwn,
+				ActionList:     ScopeOwn,
+				ActionShare:    ScopeOwn,
+			},
+			RoleViewer: {
+				ActionRead:    ScopeAny,
+				ActionList:    ScopeAny,
+				ActionListAll: ScopeAny,
+			},
+			RoleAdmin: {
+				ActionCreate:   ScopeAny,
+				ActionRead:     ScopeAny,
+				ActionUpdate:   ScopeAny,
+				ActionDelete:   ScopeAny,
+				ActionComplete: ScopeAny,
+				ActionList:     ScopeAny,
+				ActionListAll:  ScopeAny,
+				ActionShare:    ScopeAny,
+			},
+		},
+	}
+}
+
+// LoadPolicy reads a JSON policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	policy := &Policy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+
+	for role, actions := range policy.Roles {
+		for action, scope := range actions {
+			if scope != ScopeOwn && scope != ScopeAny {
+				return nil, fmt.Errorf("invalid scope %q for action %q in role %q", scope, action, role)
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// Allowed reports whether the principal may perform action on a resource
+// owned by ownerID. An empty ownerID only matches ScopeAny.
+func (p *Policy) Allowed(principal *Principal, action Action, ownerID string) bool {
+	roles := principal.Roles
+	if len(roles) == 0 && p.DefaultRole != "" {
+		roles = []string{p.DefaultRole}
+	}
+
+	for _, role := range roles {
+		switch p.Roles[role][action] {
+		case ScopeAny:
+			return true
+		case ScopeOwn:
+			if ownerID != "" && ownerID == principal.Subject {
+				return true
+			}
+		}
+	}
+
+	return false
+}
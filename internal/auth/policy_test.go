@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", `{"default_role":"owner","roles":{"owner":{"tasks.read":"own"},"auditor":{"tasks.list_all":"any"}}}`, ""},
+		{"unknown scope", `{"roles":{"owner":{"tasks.read":"everything"}}}`, `invalid scope "everything"`},
+		{"empty scope", `{"roles":{"owner":{"tasks.read":""}}}`, `invalid scope ""`},
+		{"malformed", `{"roles":`, "failed to parse policy file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := LoadPolicy(writePolicy(t, tt.content))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("LoadPolicy error = %v", err)
+				}
+				if policy.Roles["auditor"][ActionListAll] != ScopeAny {
+					t.Errorf("roles = %v, want auditor to list all tasks", policy.Roles)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadPolicy error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyAllowed(t *testing.T) {
+	policy := DefaultPolicy()
+	owner := &Principal{Subject: "alice", Roles: []string{RoleOwner}}
+	viewer := &Principal{Subject: "vera", Roles: []string{RoleViewer}}
+	admin := &Principal{Subject: "root", Roles: []string{RoleAdmin}}
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		action    Action
+		ownerID   string
+		want      bool
+	}{
+		{"owner reads own", owner, ActionRead, "alice", true},
+		{"owner deletes own", owner, ActionDelete, "alice", true},
+		{"owner reads other", owner, ActionRead, "bob", false},
+		{"owner updates other", owner, ActionUpdate, "bob", false},
+		{"owner lists all", owner, ActionListAll, "", false},
+		{"own scope needs an owner", owner, ActionRead, "", false},
+		{"viewer reads own", viewer, ActionRead, "vera", true},
+		{"viewer reads other", viewer, ActionRead, "bob", true},
+		{"viewer lists all", viewer, ActionListAll, "", true},
+		{"viewer deletes own", viewer, ActionDelete, "vera", false},
+		{"viewer updates other", viewer, ActionUpdate, "bob", false},
+		{"admin deletes other", admin, ActionDelete, "bob", true},
+		{"admin shares other", admin, ActionShare, "bob", true},
+		{"admin lists all", admin, ActionListAll, "", true},
+		{"no roles uses the default role", &Principal{Subject: "alice"}, ActionUpdate, "alice", true},
+		{"default role is not admin", &Principal{Subject: "alice"}, ActionUpdate, "bob", false},
+		{"unknown role", &Principal{Subject: "alice", Roles: []string{"intern"}}, ActionRead, "alice", false},
+		{"roles add up", &Principal{Subject: "alice", Roles: []string{RoleViewer, RoleOwner}}, ActionDelete, "alice", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Allowed(tt.principal, tt.action, tt.ownerID); got != tt.want {
+				t.Errorf("Allowed(%v, %s, %q) = %t, want %t", tt.principal.Roles, tt.action, tt.ownerID, got, tt.want)
+			}
+		})
+	}
+}
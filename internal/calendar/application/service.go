@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+	tasksapp "github.com/Mayer-04/grpc-task-manager-go/internal/tasks/application"
+	tasksdomain "github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tracing"
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/Mayer-04/grpc-task-manager-go/internal/calendar/application")
+
+// tokenPrefix marks the feed tokens issued by this service.
+const tokenPrefix = "tmc_"
+
+type CalendarService struct {
+	feedRepo    domain.FeedRepository
+	taskService *tasksapp.TaskService
+}
+
+func NewCalendarService(feedRepo domain.FeedRepository, taskService *tasksapp.TaskService) *CalendarService {
+	return &CalendarService{
+		feedRepo:    feedRepo,
+		taskService: taskService,
+	}
+}
+
+// CreateFeed issues a feed of the caller's tasks and returns it together
+// with the plaintext token, which is never stored.
+func (s *CalendarService) CreateFeed(ctx context.Context, name string, includeShared bool) (*domain.Feed, string, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: calendar feeds require an authenticated caller", domain.ErrPermissionDenied)
+	}
+	if name == "" {
+		return nil, "", fmt.Errorf("name is required")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	token, prefix, err := generateToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	feed, err := s.feedRepo.CreateFeed(ctx, &domain.Feed{
+		ID:            id,
+		Name:          name,
+		OwnerID:       principal.Subject,
+		IncludeShared: includeShared,
+		Prefix:        prefix,
+		TokenHash:     hashToken(token),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return feed, token, nil
+}
+
+func (s *CalendarService) ListFeeds(ctx context.Context) ([]*domain.Feed, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: calendar feeds require an authenticated caller", domain.ErrPermissionDenied)
+	}
+
+	return s.feedRepo.ListFeedsByOwner(ctx, principal.Subject)
+}
+
+func (s *CalendarService) RevokeFeed(ctx context.Context, id string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w: calendar feeds require an authenticated caller", domain.ErrPermissionDenied)
+	}
+	if id == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	// Validar que sea un UUID válido
+	if _, err := uuid.FromString(id); err != nil {
+		return fmt.Errorf("invalid id format: %w", err)
+	}
+
+	feed, err := s.feedRepo.GetFeed(ctx, id)
+	if err != nil {
+		return err
+	}
+	if feed.OwnerID != principal.Subject && !slices.Contains(principal.Roles, auth.RoleAdmin) {
+		return fmt.Errorf("%w: calendar feed belongs to another user", domain.ErrPermissionDenied)
+	}
+
+	return s.feedRepo.RevokeFeed(ctx, id)
+}
+
+// FeedTodos resolves a feed token and returns the tasks of its owner as
+// VTODO entries. The tasks are read with the owner's permissions.
+func (s *CalendarService) FeedTodos(ctx context.Context, token string) (_ *domain.Feed, _ []domain.Todo, err error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.FeedTodos")
+	defer tracing.End(span, &err)
+
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, nil, domain.ErrInvalidToken
+	}
+
+	feed, err := s.feedRepo.GetFeedByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !feed.Active() {
+		return nil, nil, domain.ErrInvalidToken
+	}
+
+	ctx = auth.NewContext(ctx, &auth.Principal{Subject: feed.OwnerID})
+	tasks, err := s.taskService.ListTasksByUser(ctx, feed.OwnerID, feed.IncludeShared, tasksdomain.Page{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todos := make([]domain.Todo, 0, len(tasks))
+	for _, task := range tasks {
+		todos = append(todos, TaskToTodo(task))
+	}
+	return feed, todos, nil
+}
+
+// ImportTodo creates the task of a VTODO entry for userID.
+func (s *CalendarService) ImportTodo(ctx context.Context, userID string, todo domain.Todo) (_ *tasksdomain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.ImportTodo")
+	defer tracing.End(span, &err)
+
+	if strings.TrimSpace(todo.Summary) == "" {
+		return nil, fmt.Errorf("%w: VTODO %q has no SUMMARY", domain.ErrInvalidCalendar, todo.UID)
+	}
+
+	return s.taskService.CreateTask(ctx, userID, TodoTitle(todo), todo.Description, todo.Completed)
+}
+
+// generateToken returns a new random token and its displayable prefix.
+func generateToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+
+	token := tokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, token[:len(tokenPrefix)+8], nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
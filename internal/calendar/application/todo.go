@@ -0,0 +1,67 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+	tasksdomain "github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+)
+
+// Tasks have no due date nor priority of their own. As in the todo.txt
+// files of the client, they are kept in the title as the tags
+// due:YYYY-MM-DD and pri:A (A is the highest), which are taken out of the
+// VTODO summary and restored on import. PRIORITY only has nine levels, so
+// pri:A to pri:I map to 1 to 9 and pri:J to pri:Z stay in the summary.
+const dueDateLayout = "2006-01-02"
+
+// TaskToTodo converts a task into a VTODO entry.
+func TaskToTodo(task *tasksdomain.Task) domain.Todo {
+	todo := domain.Todo{
+		UID:          task.ID.String(),
+		Description:  task.Description,
+		Completed:    task.Completed,
+		Created:      task.CreatedAt,
+		LastModified: task.UpdatedAt,
+	}
+
+	var summary []string
+	for _, word := range strings.Fields(task.Title) {
+		key, value, _ := strings.Cut(word, ":")
+		switch {
+		case key == "due" && todo.Due == nil:
+			due, err := time.Parse(dueDateLayout, value)
+			if err != nil {
+				summary = append(summary, word)
+				continue
+			}
+			todo.Due = &due
+		case key == "pri" && todo.Priority == 0 && len(value) == 1 && value[0] >= 'A' && value[0] <= 'I':
+			// RFC 5545: 1 es la prioridad más alta y 9 la más baja
+			todo.Priority = int(value[0]-'A') + 1
+		case (key == "created" || key == "done") && isDate(value):
+			// Fechas de todo.txt: el VTODO ya lleva CREATED y LAST-MODIFIED
+		default:
+			summary = append(summary, word)
+		}
+	}
+	todo.Summary = strings.Join(summary, " ")
+	return todo
+}
+
+// TodoTitle returns the title of the task created from a VTODO entry.
+func TodoTitle(todo domain.Todo) string {
+	title := strings.Join(strings.Fields(todo.Summary), " ")
+	if todo.Due != nil {
+		title += " due:" + todo.Due.Format(dueDateLayout)
+	}
+	if todo.Priority >= 1 && todo.Priority <= 9 {
+		title += " pri:" + string(rune('A'+todo.Priority-1))
+	}
+	return title
+}
+
+func isDate(s string) bool {
+	_, err := time.Parse(dueDateLayout, s)
+	return err == nil
+}
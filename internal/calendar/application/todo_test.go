@@ -0,0 +1,80 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+	tasksdomain "github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+)
+
+func TestTaskToTodo(t *testing.T) {
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		title    string
+		summary  string
+		due      *time.Time
+		priority int
+	}{
+		{title: "plain task", summary: "plain task"},
+		{title: "ship release due:2024-03-15 pri:A", summary: "ship release", due: &due, priority: 1},
+		{title: "pri:I lowest", summary: "lowest", priority: 9},
+		{title: "pri:J keeps its letter", summary: "pri:J keeps its letter"},
+		{title: "pri:Z keeps its letter", summary: "pri:Z keeps its letter"},
+		{title: "pri:a lowercase pri:AB", summary: "pri:a lowercase pri:AB"},
+		{title: "first wins pri:B pri:C", summary: "first wins pri:C", priority: 2},
+		{title: "due:soon stays", summary: "due:soon stays"},
+		{title: "due:2024-03-15 due:2024-04-01", summary: "due:2024-04-01", due: &due},
+		{title: "dates created:2024-01-01 done:2024-01-02", summary: "dates"},
+		{title: "  extra   spaces  ", summary: "extra spaces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			todo := TaskToTodo(&tasksdomain.Task{Title: tt.title})
+			if todo.Summary != tt.summary {
+				t.Errorf("Summary = %q, want %q", todo.Summary, tt.summary)
+			}
+			if todo.Priority != tt.priority {
+				t.Errorf("Priority = %d, want %d", todo.Priority, tt.priority)
+			}
+			switch {
+			case tt.due == nil && todo.Due != nil:
+				t.Errorf("Due = %v, want none", *todo.Due)
+			case tt.due != nil && (todo.Due == nil || !todo.Due.Equal(*tt.due)):
+				t.Errorf("Due = %v, want %v", todo.Due, *tt.due)
+			}
+		})
+	}
+}
+
+func TestTodoTitle(t *testing.T) {
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		todo domain.Todo
+		want string
+	}{
+		{domain.Todo{Summary: "plain task"}, "plain task"},
+		{domain.Todo{Summary: "ship\n release ", Due: &due, Priority: 1}, "ship release due:2024-03-15 pri:A"},
+		{domain.Todo{Summary: "lowest", Priority: 9}, "lowest pri:I"},
+		{domain.Todo{Summary: "undefined", Priority: 0}, "undefined"},
+		{domain.Todo{Summary: "out of range", Priority: 10}, "out of range"},
+	}
+
+	for _, tt := range tests {
+		if got := TodoTitle(tt.todo); got != tt.want {
+			t.Errorf("TodoTitle(%+v) = %q, want %q", tt.todo, got, tt.want)
+		}
+	}
+}
+
+// TestTodoRoundTrip checks that every priority letter survives an export
+// and import, either as PRIORITY or in the summary.
+func TestTodoRoundTrip(t *testing.T) {
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		title := "task pri:" + string(letter)
+		if got := TodoTitle(TaskToTodo(&tasksdomain.Task{Title: title})); got != title {
+			t.Errorf("round trip of %q = %q", title, got)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Feed is an iCalendar subscription to the tasks of a user. Calendar apps
+// cannot send headers, so the feed is authenticated by a secret token in
+// its URL; revoking the feed invalidates the URL.
+type Feed struct {
+	ID            uuid.UUID
+	Name          string
+	OwnerID       string
+	IncludeShared bool
+	Prefix        string
+	TokenHash     string
+	CreatedAt     time.Time
+	RevokedAt     *time.Time
+}
+
+// Active reports whether the feed can still be used.
+func (f *Feed) Active() bool {
+	return f.RevokedAt == nil
+}
+
+// Todo is a task as a VTODO entry of an iCalendar file.
+type Todo struct {
+	UID          string
+	Summary      string
+	Description  string
+	Completed    bool
+	Due          *time.Time // solo la fecha: el título guarda due:YYYY-MM-DD
+	Priority     int        // 1 (alta) a 9 (baja), 0 sin prioridad
+	Created      time.Time
+	LastModified time.Time
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("calendar feed not found")
+	ErrInvalidToken     = errors.New("invalid calendar feed token")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrInvalidCalendar  = errors.New("invalid calendar")
+)
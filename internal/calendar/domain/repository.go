@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+type FeedRepository interface {
+	CreateFeed(ctx context.Context, feed *Feed) (*Feed, error)
+	GetFeed(ctx context.Context, id string) (*Feed, error)
+	GetFeedByHash(ctx context.Context, tokenHash string) (*Feed, error)
+	ListFeedsByOwner(ctx context.Context, ownerID string) ([]*Feed, error)
+	RevokeFeed(ctx context.Context, id string) error
+}
@@ -0,0 +1,147 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/application"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxImportErrors bounds the per-entry errors returned by ImportCalendar,
+// as in ImportTasks.
+const maxImportErrors = 100
+
+type CalendarHandler struct {
+	taskpb.UnimplementedCalendarServiceServer
+	calendarService *application.CalendarService
+}
+
+func NewCalendarHandler(calendarService *application.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+func (h *CalendarHandler) CreateCalendarFeed(ctx context.Context, req *taskpb.CreateCalendarFeedRequest) (*taskpb.CreateCalendarFeedResponse, error) {
+	feed, token, err := h.calendarService.CreateFeed(ctx, req.Name, req.IncludeShared)
+	if err != nil {
+		return nil, toStatusError(err, "failed to create calendar feed")
+	}
+
+	return &taskpb.CreateCalendarFeedResponse{
+		Feed:  h.domainFeedToProto(feed),
+		Token: token,
+		Path:  FeedPath(token),
+	}, nil
+}
+
+func (h *CalendarHandler) ListCalendarFeeds(ctx context.Context, req *taskpb.ListCalendarFeedsRequest) (*taskpb.ListCalendarFeedsResponse, error) {
+	feeds, err := h.calendarService.ListFeeds(ctx)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list calendar feeds")
+	}
+
+	var protoFeeds []*taskpb.CalendarFeed
+	for _, feed := range feeds {
+		protoFeeds = append(protoFeeds, h.domainFeedToProto(feed))
+	}
+
+	return &taskpb.ListCalendarFeedsResponse{
+		Feeds: protoFeeds,
+	}, nil
+}
+
+func (h *CalendarHandler) RevokeCalendarFeed(ctx context.Context, req *taskpb.RevokeCalendarFeedRequest) (*taskpb.RevokeCalendarFeedResponse, error) {
+	err := h.calendarService.RevokeFeed(ctx, req.Id)
+	if err != nil {
+		return &taskpb.RevokeCalendarFeedResponse{
+			Success: false,
+			Message: err.Error(),
+		}, toStatusError(err, "failed to revoke calendar feed")
+	}
+
+	return &taskpb.RevokeCalendarFeedResponse{
+		Success: true,
+		Message: "Calendar feed revoked successfully",
+	}, nil
+}
+
+// ImportCalendar creates a task for every VTODO of the calendar. A failing
+// entry does not stop the import; it is counted and reported in the summary.
+func (h *CalendarHandler) ImportCalendar(ctx context.Context, req *taskpb.ImportCalendarRequest) (*taskpb.ImportTasksResponse, error) {
+	todos, err := ReadTodos(strings.NewReader(req.Ics))
+	if err != nil {
+		return nil, toStatusError(err, "failed to read calendar")
+	}
+
+	userID := req.UserId
+	if principal, ok := auth.FromContext(ctx); ok && userID == "" {
+		userID = principal.Subject
+	}
+
+	summary := &taskpb.ImportTasksResponse{}
+	for i, todo := range todos {
+		_, err := h.calendarService.ImportTodo(ctx, userID, todo)
+		// Si vence el deadline no tiene sentido seguir con el resto
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+
+		if err == nil {
+			summary.Created++
+			continue
+		}
+		summary.Failed++
+		if len(summary.Errors) < maxImportErrors {
+			summary.Errors = append(summary.Errors, &taskpb.ImportError{
+				Index:   int32(i + 1),
+				TaskId:  todo.UID,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return summary, nil
+}
+
+// toStatusError converts a service error into a gRPC status.
+func toStatusError(err error, msg string) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, domain.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, domain.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrInvalidCalendar):
+		code = codes.InvalidArgument
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
+
+// domainFeedToProto converts a domain.Feed to a taskpb.CalendarFeed.
+func (h *CalendarHandler) domainFeedToProto(feed *domain.Feed) *taskpb.CalendarFeed {
+	return &taskpb.CalendarFeed{
+		Id:            feed.ID.String(),
+		Name:          feed.Name,
+		OwnerId:       feed.OwnerID,
+		IncludeShared: feed.IncludeShared,
+		Prefix:        feed.Prefix,
+		CreatedAt:     timestamppb.New(feed.CreatedAt),
+		RevokedAt:     optionalTimestamp(feed.RevokedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
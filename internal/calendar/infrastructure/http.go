@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/application"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+)
+
+// FeedPattern is the route of the ICS feeds on the HTTP server.
+const FeedPattern = "GET /calendar/{file}"
+
+// FeedPath returns the path of the feed with the given token.
+func FeedPath(token string) string {
+	return "/calendar/" + token + ".ics"
+}
+
+// FeedHandler serves the ICS feeds. The token in the path is the only
+// credential, so unknown and revoked tokens are answered as not found.
+type FeedHandler struct {
+	calendarService *application.CalendarService
+	timeout         time.Duration
+}
+
+func NewFeedHandler(calendarService *application.CalendarService, timeout time.Duration) *FeedHandler {
+	return &FeedHandler{
+		calendarService: calendarService,
+		timeout:         timeout,
+	}
+}
+
+func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	feed, todos, err := h.calendarService.FeedTodos(ctx, token)
+	if errors.Is(err, domain.ErrInvalidToken) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to serve calendar feed", "error", err)
+		http.Error(w, "failed to load tasks", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	// Los calendarios consultan el feed periódicamente; no debe guardarse en cachés compartidas
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if err := WriteCalendar(w, feed.Name, todos); err != nil {
+		slog.WarnContext(ctx, "Failed to write calendar feed", "feed_id", feed.ID, "error", err)
+	}
+}
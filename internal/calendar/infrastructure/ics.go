@@ -0,0 +1,305 @@
+package infrastructure
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+)
+
+// iCalendar (RFC 5545) encoding of tasks as VTODO entries.
+
+const (
+	icsDateLayout     = "20060102"
+	icsDateTimeLayout = "20060102T150405Z"
+	icsLocalLayout    = "20060102T150405"
+
+	// maxLineOctets is the longest content line before folding.
+	maxLineOctets = 75
+)
+
+// icsWriter writes content lines folded and terminated with CRLF.
+type icsWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (w *icsWriter) line(name, value string) {
+	if w.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+	// Se pliega sin partir caracteres UTF-8; las continuaciones empiezan por
+	// un espacio, que cuenta para el límite
+	limit := maxLineOctets
+	for len(line) > limit && w.err == nil {
+		cut := limit
+		for cut > 0 && !utf8Start(line[cut]) {
+			cut--
+		}
+		_, w.err = w.w.WriteString(line[:cut] + "\r\n ")
+		line, limit = line[cut:], maxLineOctets-1
+	}
+	if w.err == nil {
+		_, w.err = w.w.WriteString(line + "\r\n")
+	}
+}
+
+func utf8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// WriteCalendar writes the todos as an iCalendar file named name.
+func WriteCalendar(out io.Writer, name string, todos []domain.Todo) error {
+	w := &icsWriter{w: bufio.NewWriter(out)}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//grpc-task-manager-go//Tasks//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("X-WR-CALNAME", escapeText(name))
+
+	for _, todo := range todos {
+		w.line("BEGIN", "VTODO")
+		w.line("UID", todo.UID)
+		w.line("DTSTAMP", todo.LastModified.UTC().Format(icsDateTimeLayout))
+		w.line("CREATED", todo.Created.UTC().Format(icsDateTimeLayout))
+		w.line("LAST-MODIFIED", todo.LastModified.UTC().Format(icsDateTimeLayout))
+		w.line("SUMMARY", escapeText(todo.Summary))
+		if todo.Description != "" {
+			w.line("DESCRIPTION", escapeText(todo.Description))
+		}
+		if todo.Due != nil {
+			w.line("DUE;VALUE=DATE", todo.Due.Format(icsDateLayout))
+		}
+		if todo.Priority > 0 {
+			w.line("PRIORITY", strconv.Itoa(todo.Priority))
+		}
+		if todo.Completed {
+			w.line("STATUS", "COMPLETED")
+			w.line("PERCENT-COMPLETE", "100")
+			// La tarea no guarda cuándo se completó; la última modificación es lo más cercano
+			w.line("COMPLETED", todo.LastModified.UTC().Format(icsDateTimeLayout))
+		} else {
+			w.line("STATUS", "NEEDS-ACTION")
+		}
+		w.line("END", "VTODO")
+	}
+
+	w.line("END", "VCALENDAR")
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+// ReadTodos parses the VTODO entries of an iCalendar file. Other
+// components, such as VEVENT or the VALARM of a VTODO, are ignored.
+func ReadTodos(r io.Reader) ([]domain.Todo, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		todos    []domain.Todo
+		stack    []string
+		todo     *domain.Todo
+		calendar bool
+	)
+	for _, l := range lines {
+		name, params, value, err := parseContentLine(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidCalendar, l.number, err)
+		}
+
+		switch name {
+		case "BEGIN":
+			component := strings.ToUpper(value)
+			stack = append(stack, component)
+			if component == "VCALENDAR" {
+				calendar = true
+			}
+			if component == "VTODO" && len(stack) == 2 {
+				todo = &domain.Todo{}
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", domain.ErrInvalidCalendar, l.number, value)
+			}
+			stack = stack[:len(stack)-1]
+			if component == "VTODO" && todo != nil && len(stack) == 1 {
+				todos = append(todos, *todo)
+				todo = nil
+			}
+			continue
+		}
+
+		// Solo interesan las propiedades del propio VTODO, no de sus subcomponentes
+		if todo == nil || len(stack) != 2 {
+			continue
+		}
+		if err := setTodoProperty(todo, name, params, value); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidCalendar, l.number, err)
+		}
+	}
+
+	if !calendar {
+		return nil, fmt.Errorf("%w: missing BEGIN:VCALENDAR", domain.ErrInvalidCalendar)
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", domain.ErrInvalidCalendar, stack[len(stack)-1])
+	}
+	return todos, nil
+}
+
+func setTodoProperty(todo *domain.Todo, name string, params map[string]string, value string) error {
+	switch name {
+	case "UID":
+		todo.UID = value
+	case "SUMMARY":
+		todo.Summary = unescapeText(value)
+	case "DESCRIPTION":
+		todo.Description = unescapeText(value)
+	case "STATUS":
+		todo.Completed = strings.EqualFold(value, "COMPLETED")
+	case "COMPLETED":
+		todo.Completed = true
+	case "PERCENT-COMPLETE":
+		if value == "100" {
+			todo.Completed = true
+		}
+	case "PRIORITY":
+		priority, err := strconv.Atoi(value)
+		if err != nil || priority < 0 || priority > 9 {
+			return fmt.Errorf("invalid PRIORITY %q", value)
+		}
+		todo.Priority = priority
+	case "DUE":
+		due, err := parseDate(value, params)
+		if err != nil {
+			return fmt.Errorf("invalid DUE %q", value)
+		}
+		todo.Due = &due
+	}
+	return nil
+}
+
+// parseDate parses a DATE or DATE-TIME value, keeping only the date in the
+// time zone of the value.
+func parseDate(value string, params map[string]string) (time.Time, error) {
+	if len(value) == len(icsDateLayout) {
+		return time.Parse(icsDateLayout, value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icsDateTimeLayout, value)
+		return truncateToDate(t), err
+	}
+
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		// Sin la base de zonas horarias, la hora local se toma como UTC
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+	t, err := time.ParseInLocation(icsLocalLayout, value, location)
+	return truncateToDate(t), err
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type contentLine struct {
+	text   string
+	number int
+}
+
+// unfoldLines joins the folded lines, which continue with a space or a tab.
+func unfoldLines(r io.Reader) ([]contentLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []contentLine
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text == "" {
+			continue
+		}
+		lines = append(lines, contentLine{text: text, number: number})
+	}
+	return lines, scanner.Err()
+}
+
+// parseContentLine splits "NAME;PARAM=VALUE:value" into its parts. Colons
+// and semicolons inside quoted parameter values do not split.
+func parseContentLine(line string) (name string, params map[string]string, value string, err error) {
+	quoted := false
+	separator := -1
+	for i := 0; i < len(line) && separator < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				separator = i
+			}
+		}
+	}
+	if separator <= 0 {
+		return "", nil, "", fmt.Errorf("invalid content line %q", line)
+	}
+
+	head, value := line[:separator], line[separator+1:]
+	parts := splitParams(head)
+	params = make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, paramValue, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(paramValue, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value, nil
+}
+
+func splitParams(head string) []string {
+	var (
+		parts  []string
+		start  int
+		quoted bool
+	)
+	for i := 0; i < len(head); i++ {
+		switch head[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, head[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, head[start:])
+}
+
+var (
+	textEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
@@ -0,0 +1,165 @@
+package infrastructure
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+)
+
+func TestWriteCalendarRoundTrip(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	modified := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	todos := []domain.Todo{
+		{
+			UID:          "1",
+			Summary:      "plain task",
+			Created:      created,
+			LastModified: modified,
+		},
+		{
+			UID:          "2",
+			Summary:      `special; characters, and \backslashes\`,
+			Description:  "line 1\nline 2\r\nline 3",
+			Completed:    true,
+			Due:          &due,
+			Priority:     3,
+			Created:      created,
+			LastModified: modified,
+		},
+		{
+			UID:          "3",
+			Summary:      strings.Repeat("ñandú ☃ ", 40),
+			Description:  strings.Repeat("a long description that must be folded ", 10),
+			Created:      created,
+			LastModified: modified,
+		},
+	}
+
+	var data bytes.Buffer
+	if err := WriteCalendar(&data, "Work; tasks, all", todos); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, line := range strings.Split(strings.TrimSuffix(data.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d has %d octets: %q", i+1, len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 character: %q", i+1, line)
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(data.String(), "\r\n", ""), "\n") {
+		t.Error("calendar has a bare LF")
+	}
+	if !strings.Contains(data.String(), `X-WR-CALNAME:Work\; tasks\, all`) {
+		t.Errorf("calendar name is not escaped:\n%s", data.String())
+	}
+
+	got, err := ReadTodos(&data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Los saltos CRLF de la descripción vuelven como LF
+	todos[1].Description = "line 1\nline 2\nline 3"
+	// CREATED y LAST-MODIFIED no se leen: la tarea importada tiene los suyos
+	for i := range todos {
+		todos[i].Created, todos[i].LastModified = time.Time{}, time.Time{}
+	}
+	if !reflect.DeepEqual(got, todos) {
+		t.Errorf("ReadTodos = %+v, want %+v", got, todos)
+	}
+}
+
+func TestReadTodos(t *testing.T) {
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		ics  string
+		want []domain.Todo
+	}{
+		{
+			name: "folded lines",
+			ics: "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:1\r\nSUMMARY:a long\r\n  folded\r\n\t summary\r\n" +
+				"DESCRIPTION:first\\nsecond\\, third\\;\\\\\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			want: []domain.Todo{{UID: "1", Summary: "a long folded summary", Description: "first\nsecond, third;\\"}},
+		},
+		{
+			name: "LF line endings and lowercase names",
+			ics:  "begin:vcalendar\nbegin:vtodo\nuid:1\nsummary:task\nstatus:completed\nend:vtodo\nend:vcalendar\n",
+			want: []domain.Todo{{UID: "1", Summary: "task", Completed: true}},
+		},
+		{
+			name: "completion properties",
+			ics: "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:1\nPERCENT-COMPLETE:100\nEND:VTODO\n" +
+				"BEGIN:VTODO\nUID:2\nCOMPLETED:20240101T000000Z\nEND:VTODO\n" +
+				"BEGIN:VTODO\nUID:3\nPERCENT-COMPLETE:50\nSTATUS:IN-PROCESS\nEND:VTODO\nEND:VCALENDAR\n",
+			want: []domain.Todo{{UID: "1", Completed: true}, {UID: "2", Completed: true}, {UID: "3"}},
+		},
+		{
+			name: "due dates",
+			ics: "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:1\nDUE;VALUE=DATE:20240315\nEND:VTODO\n" +
+				"BEGIN:VTODO\nUID:2\nDUE:20240315T235959Z\nEND:VTODO\n" +
+				"BEGIN:VTODO\nUID:3\nDUE;TZID=\"Europe/Madrid\":20240315T090000\nPRIORITY:5\nEND:VTODO\nEND:VCALENDAR\n",
+			want: []domain.Todo{{UID: "1", Due: &due}, {UID: "2", Due: &due}, {UID: "3", Due: &due, Priority: 5}},
+		},
+		{
+			name: "alarms and events are ignored",
+			ics: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:event\nSUMMARY:meeting\nEND:VEVENT\n" +
+				"BEGIN:VTODO\nUID:1\nSUMMARY:task\nBEGIN:VALARM\nDESCRIPTION:reminder\nSUMMARY:alarm\nEND:VALARM\nEND:VTODO\n" +
+				"END:VCALENDAR\n",
+			want: []domain.Todo{{UID: "1", Summary: "task"}},
+		},
+		{
+			name: "quoted parameter with a colon",
+			ics:  "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:1\nSUMMARY;ALTREP=\"http://example.com/a;b\":task: part 2\nEND:VTODO\nEND:VCALENDAR\n",
+			want: []domain.Todo{{UID: "1", Summary: "task: part 2"}},
+		},
+		{
+			name: "no todos",
+			ics:  "BEGIN:VCALENDAR\nVERSION:2.0\nEND:VCALENDAR\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadTodos(strings.NewReader(tt.ics))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadTodos = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadTodosErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{"empty", ""},
+		{"no calendar", "BEGIN:VTODO\nUID:1\nEND:VTODO\n"},
+		{"missing END", "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:1\n"},
+		{"mismatched END", "BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VCALENDAR\n"},
+		{"line without colon", "BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY task\nEND:VTODO\nEND:VCALENDAR\n"},
+		{"invalid priority", "BEGIN:VCALENDAR\nBEGIN:VTODO\nPRIORITY:10\nEND:VTODO\nEND:VCALENDAR\n"},
+		{"invalid due date", "BEGIN:VCALENDAR\nBEGIN:VTODO\nDUE:tomorrow\nEND:VTODO\nEND:VCALENDAR\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadTodos(strings.NewReader(tt.ics))
+			if !errors.Is(err, domain.ErrInvalidCalendar) {
+				t.Errorf("ReadTodos error = %v, want %v", err, domain.ErrInvalidCalendar)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/calendar/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/database"
+	"github.com/jackc/pgx/v5"
+)
+
+type FeedRepositoryImpl struct {
+	dbpool database.Querier
+}
+
+func NewFeedRepository(dbPool database.Querier) domain.FeedRepository {
+	return &FeedRepositoryImpl{
+		dbpool: dbPool,
+	}
+}
+
+const feedColumns = "id, name, owner_id, include_shared, prefix, token_hash, created_at, revoked_at"
+
+func scanFeed(row pgx.Row) (*domain.Feed, error) {
+	feed := &domain.Feed{}
+	err := row.Scan(
+		&feed.ID,
+		&feed.Name,
+		&feed.OwnerID,
+		&feed.IncludeShared,
+		&feed.Prefix,
+		&feed.TokenHash,
+		&feed.CreatedAt,
+		&feed.RevokedAt,
+	)
+	return feed, err
+}
+
+func (r *FeedRepositoryImpl) CreateFeed(ctx context.Context, feed *domain.Feed) (*domain.Feed, error) {
+	const query = `
+		INSERT INTO calendar_feeds (id, name, owner_id, include_shared, prefix, token_hash)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + feedColumns + `;`
+
+	result, err := scanFeed(r.dbpool.QueryRow(ctx, query, feed.ID, feed.Name, feed.OwnerID, feed.IncludeShared, feed.Prefix, feed.TokenHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert calendar feed: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *FeedRepositoryImpl) GetFeed(ctx context.Context, id string) (*domain.Feed, error) {
+	const query = `SELECT ` + feedColumns + ` FROM calendar_feeds WHERE id = $1;`
+
+	feed, err := scanFeed(r.dbpool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+func (r *FeedRepositoryImpl) GetFeedByHash(ctx context.Context, tokenHash string) (*domain.Feed, error) {
+	const query = `SELECT ` + feedColumns + ` FROM calendar_feeds WHERE token_hash = $1;`
+
+	feed, err := scanFeed(r.dbpool.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to retrieve calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+func (r *FeedRepositoryImpl) ListFeedsByOwner(ctx context.Context, ownerID string) ([]*domain.Feed, error) {
+	const query = `SELECT ` + feedColumns + ` FROM calendar_feeds WHERE owner_id = $1 ORDER BY created_at;`
+
+	rows, err := r.dbpool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendar feeds: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []*domain.Feed
+	for rows.Next() {
+		feed, err := scanFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan calendar feed: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating calendar feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+func (r *FeedRepositoryImpl) RevokeFeed(ctx context.Context, id string) error {
+	const query = "UPDATE calendar_feeds SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;"
+
+	result, err := r.dbpool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("could not revoke calendar feed: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
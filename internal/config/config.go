@@ -0,0 +1,296 @@
+// Package config defines the typed server configuration. Values are layered
+// from lowest to highest precedence: defaults, a YAML or TOML file,
+// environment variables and command-line flags.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/ratelimit"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tlsconfig"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tracing"
+)
+
+// Each leaf field declares its key in the config file (yaml/toml tags), the
+// environment variable that overrides it (env) and whether it must be hidden
+// when printed (secret). Flag names are derived from the file keys, e.g.
+// -database.max-conns.
+type Config struct {
+	Server    Server    `yaml:"server" toml:"server"`
+	Database  Database  `yaml:"database" toml:"database"`
+	Log       Log       `yaml:"log" toml:"log"`
+	Tracing   Tracing   `yaml:"tracing" toml:"tracing"`
+	TLS       TLS       `yaml:"tls" toml:"tls"`
+	Auth      Auth      `yaml:"auth" toml:"auth"`
+	RPC       RPC       `yaml:"rpc" toml:"rpc"`
+	RateLimit RateLimit `yaml:"rate_limit" toml:"rate_limit"`
+	CORS      CORS      `yaml:"cors" toml:"cors"`
+}
+
+type Server struct {
+	Port                string        `yaml:"port" toml:"port" env:"PORT"`
+	HTTPPort            string        `yaml:"http_port" toml:"http_port" env:"HTTP_PORT"`
+	H2C                 bool          `yaml:"h2c" toml:"h2c" env:"HTTP_H2C"`
+	MetricsPort         string        `yaml:"metrics_port" toml:"metrics_port" env:"METRICS_PORT"`
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" toml:"health_check_interval" env:"HEALTH_CHECK_INTERVAL"`
+}
+
+// Database accepts either a full DSN or discrete fields. With a DSN the
+// discrete connection fields are ignored, but the pool, timeout and retry
+// settings still apply.
+type Database struct {
+	DSN              string        `yaml:"dsn" toml:"dsn" env:"DATABASE_URL" secret:"true"`
+	Host             string        `yaml:"host" toml:"host" env:"POSTGRES_HOST"`
+	Port             string        `yaml:"port" toml:"port" env:"POSTGRES_PORT"`
+	User             string        `yaml:"user" toml:"user" env:"POSTGRES_USER"`
+	Password         string        `yaml:"password" toml:"password" env:"POSTGRES_PASSWORD" secret:"true"`
+	Name             string        `yaml:"name" toml:"name" env:"POSTGRES_DB"`
+	SSLMode          string        `yaml:"sslmode" toml:"sslmode" env:"POSTGRES_SSLMODE"`
+	MaxConns         int32         `yaml:"max_conns" toml:"max_conns" env:"POSTGRES_MAX_CONNS"`
+	MinConns         int32         `yaml:"min_conns" toml:"min_conns" env:"POSTGRES_MIN_CONNS"`
+	StatementTimeout time.Duration `yaml:"statement_timeout" toml:"statement_timeout" env:"POSTGRES_STATEMENT_TIMEOUT"`
+	LockTimeout      time.Duration `yaml:"lock_timeout" toml:"lock_timeout" env:"POSTGRES_LOCK_TIMEOUT"`
+
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" toml:"max_conn_lifetime" env:"POSTGRES_MAX_CONN_LIFETIME"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" toml:"max_conn_idle_time" env:"POSTGRES_MAX_CONN_IDLE_TIME"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" toml:"health_check_period" env:"POSTGRES_HEALTH_CHECK_PERIOD"`
+	ConnectTimeout    time.Duration `yaml:"connect_timeout" toml:"connect_timeout" env:"POSTGRES_CONNECT_TIMEOUT"`
+
+	StartupTimeout time.Duration `yaml:"startup_timeout" toml:"startup_timeout" env:"POSTGRES_STARTUP_TIMEOUT"` // reintentos al arrancar
+	RetryAttempts  int           `yaml:"retry_attempts" toml:"retry_attempts" env:"POSTGRES_RETRY_ATTEMPTS"`    // por sentencia, incluido el primer intento
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay" toml:"retry_base_delay" env:"POSTGRES_RETRY_BASE_DELAY"`
+	RetryMaxDelay  time.Duration `yaml:"retry_max_delay" toml:"retry_max_delay" env:"POSTGRES_RETRY_MAX_DELAY"`
+}
+
+type Log struct {
+	Format             string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+	Level              string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	RedactDescriptions bool   `yaml:"redact_descriptions" toml:"redact_descriptions" env:"LOG_REDACT_DESCRIPTIONS"`
+}
+
+type Tracing struct {
+	Exporter tracing.Exporter `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	File     string           `yaml:"file" toml:"file" env:"TRACING_FILE"`
+}
+
+type TLS struct {
+	CertFile       string               `yaml:"cert_file" toml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile        string               `yaml:"key_file" toml:"key_file" env:"TLS_KEY_FILE"`
+	ClientCAFile   string               `yaml:"client_ca_file" toml:"client_ca_file" env:"TLS_CLIENT_CA_FILE"`
+	ClientAuth     tlsconfig.ClientAuth `yaml:"client_auth" toml:"client_auth" env:"TLS_CLIENT_AUTH"`
+	ReloadInterval time.Duration        `yaml:"reload_interval" toml:"reload_interval" env:"TLS_RELOAD_INTERVAL"`
+}
+
+// Enabled reports whether the gRPC and HTTP listeners use TLS.
+func (t TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// VerifiesClients reports whether client certificates are requested.
+func (t TLS) VerifiesClients() bool {
+	return t.ClientAuth != "" && t.ClientAuth != tlsconfig.ClientAuthNone
+}
+
+type Auth struct {
+	Disabled    bool   `yaml:"disabled" toml:"disabled" env:"AUTH_DISABLED"`
+	PolicyFile  string `yaml:"policy_file" toml:"policy_file" env:"AUTHZ_POLICY_FILE"`
+	JWTSecret   string `yaml:"jwt_secret" toml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	JWKSFile    string `yaml:"jwks_file" toml:"jwks_file" env:"JWT_JWKS_FILE"`
+	JWTIssuer   string `yaml:"jwt_issuer" toml:"jwt_issuer" env:"JWT_ISSUER"`
+	JWTAudience string `yaml:"jwt_audience" toml:"jwt_audience" env:"JWT_AUDIENCE"`
+}
+
+// minJWTSecretLength is the shortest HS256 secret accepted, the size of a
+// SHA-256 hash.
+const minJWTSecretLength = 32
+
+// placeholderSecrets are fragments of the sample values of documentation
+// and examples, which must never sign real tokens.
+var placeholderSecrets = []string{"change-me", "changeme", "change_me", "replace-me", "your-secret", "example", "secret-key"}
+
+func isPlaceholderSecret(secret string) bool {
+	lower := strings.ToLower(secret)
+	for _, placeholder := range placeholderSecrets {
+		if strings.Contains(lower, placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// JWTEnabled reports whether bearer tokens can be verified.
+func (a Auth) JWTEnabled() bool {
+	return a.JWTSecret != "" || a.JWKSFile != ""
+}
+
+// StreamMethodTimeouts are the built-in deadlines of the streaming RPCs,
+// which cover a whole transfer or bulk operation and would not fit in the
+// default timeout. rpc.method_timeouts is applied on top of them.
+const StreamMethodTimeouts = "ExportTasks=2m:10m,ImportTasks=2m:10m,CompleteTasksMatching=1m:5m,DeleteTasksMatching=1m:5m"
+
+type RPC struct {
+	DefaultTimeout time.Duration `yaml:"default_timeout" toml:"default_timeout" env:"RPC_DEFAULT_TIMEOUT"`
+	MaxTimeout     time.Duration `yaml:"max_timeout" toml:"max_timeout" env:"RPC_MAX_TIMEOUT"`
+	MethodTimeouts string        `yaml:"method_timeouts" toml:"method_timeouts" env:"RPC_METHOD_TIMEOUTS"` // "ListAllTasks=20s:60s,GetTask=2s:5s"
+}
+
+type RateLimit struct {
+	Enabled  bool   `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	Backend  string `yaml:"backend" toml:"backend" env:"RATE_LIMIT_BACKEND"`
+	Limits   string `yaml:"limits" toml:"limits" env:"RATE_LIMITS"`       // "default=10:20,ListAllTasks=1:5"
+	IPLimit  string `yaml:"ip_limit" toml:"ip_limit" env:"RATE_LIMIT_IP"` // "50:100" por IP antes de autenticar; vacío lo desactiva
+	FailOpen bool   `yaml:"fail_open" toml:"fail_open" env:"RATE_LIMIT_FAIL_OPEN"`
+}
+
+type CORS struct {
+	AllowedOrigins []string `yaml:"allowed_origins" toml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods []string `yaml:"allowed_methods" toml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders []string `yaml:"allowed_headers" toml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	MaxAge         int      `yaml:"max_age" toml:"max_age" env:"CORS_MAX_AGE"`
+}
+
+// Default returns the configuration used when nothing else is set.
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Port:                "50051",
+			HTTPPort:            "8080",
+			MetricsPort:         "9090",
+			HealthCheckInterval: 10 * time.Second,
+		},
+		Database: Database{
+			Host:    "localhost",
+			Port:    "5432",
+			Name:    "taskdb",
+			SSLMode: "disable",
+
+			ConnectTimeout: 5 * time.Second,
+			StartupTimeout: time.Minute,
+			RetryAttempts:  3,
+			RetryBaseDelay: 50 * time.Millisecond,
+			RetryMaxDelay:  time.Second,
+		},
+		Log: Log{
+			Format:             "json",
+			Level:              "info",
+			RedactDescriptions: true,
+		},
+		Tracing: Tracing{
+			Exporter: tracing.ExporterNone,
+			File:     "traces.json",
+		},
+		TLS: TLS{
+			ClientAuth:     tlsconfig.ClientAuthNone,
+			ReloadInterval: 30 * time.Second,
+		},
+		RPC: RPC{
+			DefaultTimeout: 10 * time.Second,
+			MaxTimeout:     30 * time.Second,
+		},
+		RateLimit: RateLimit{
+			Enabled: true,
+			Backend: "memory",
+			IPLimit: "50:100",
+		},
+	}
+}
+
+var sslModes = map[string]bool{
+	"disable": true, "allow": true, "prefer": true,
+	"require": true, "verify-ca": true, "verify-full": true,
+}
+
+// Validate checks the whole configuration and reports every problem found.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	for _, port := range []struct{ name, value string }{
+		{"server.port", c.Server.Port},
+		{"server.http_port", c.Server.HTTPPort},
+		{"server.metrics_port", c.Server.MetricsPort},
+	} {
+		n, err := strconv.Atoi(port.value)
+		check(err == nil && n >= 0 && n <= 65535, "%s: invalid port %q", port.name, port.value)
+	}
+	check(c.Server.HealthCheckInterval > 0, "server.health_check_interval must be positive")
+
+	if c.Database.DSN == "" {
+		check(c.Database.Host != "", "database.host is required when database.dsn is empty")
+		check(c.Database.Name != "", "database.name is required when database.dsn is empty")
+		check(sslModes[c.Database.SSLMode], "database.sslmode: invalid mode %q", c.Database.SSLMode)
+	}
+	check(c.Database.MaxConns >= 0 && c.Database.MinConns >= 0, "database pool sizes cannot be negative")
+	check(c.Database.MaxConns == 0 || c.Database.MinConns <= c.Database.MaxConns,
+		"database.min_conns (%d) cannot exceed database.max_conns (%d)", c.Database.MinConns, c.Database.MaxConns)
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"database.statement_timeout", c.Database.StatementTimeout},
+		{"database.lock_timeout", c.Database.LockTimeout},
+		{"database.max_conn_lifetime", c.Database.MaxConnLifetime},
+		{"database.max_conn_idle_time", c.Database.MaxConnIdleTime},
+		{"database.health_check_period", c.Database.HealthCheckPeriod},
+		{"database.connect_timeout", c.Database.ConnectTimeout},
+		{"database.startup_timeout", c.Database.StartupTimeout},
+		{"database.retry_base_delay", c.Database.RetryBaseDelay},
+		{"database.retry_max_delay", c.Database.RetryMaxDelay},
+	} {
+		check(d.value >= 0, "%s cannot be negative", d.name)
+	}
+	check(c.Database.RetryAttempts >= 1, "database.retry_attempts must be at least 1")
+
+	check(c.Log.Format == "json" || c.Log.Format == "text", "log.format: invalid format %q", c.Log.Format)
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout, tracing.ExporterFile:
+	default:
+		check(false, "tracing.exporter: unknown exporter %q", c.Tracing.Exporter)
+	}
+
+	switch c.TLS.ClientAuth {
+	case tlsconfig.ClientAuthNone, tlsconfig.ClientAuthOptional, tlsconfig.ClientAuthRequire:
+	default:
+		check(false, "tls.client_auth: invalid mode %q", c.TLS.ClientAuth)
+	}
+	check(!c.TLS.Enabled() || c.TLS.KeyFile != "", "tls.key_file is required with tls.cert_file")
+	check(c.TLS.Enabled() || !c.TLS.VerifiesClients(), "tls.client_auth requires tls.cert_file and tls.key_file")
+	check(!c.TLS.VerifiesClients() || c.TLS.ClientCAFile != "", "tls.client_auth requires tls.client_ca_file")
+	check(c.TLS.ReloadInterval > 0, "tls.reload_interval must be positive")
+
+	if c.Auth.JWTSecret != "" {
+		check(len(c.Auth.JWTSecret) >= minJWTSecretLength,
+			"auth.jwt_secret must be at least %d bytes, e.g. the output of `openssl rand -base64 32`", minJWTSecretLength)
+		check(!isPlaceholderSecret(c.Auth.JWTSecret), "auth.jwt_secret is a placeholder, generate a random secret")
+	}
+	check(c.Auth.Disabled || c.Auth.JWTEnabled() || c.TLS.VerifiesClients(),
+		"auth: set auth.jwt_secret, auth.jwks_file or tls.client_auth, or auth.disabled=true")
+
+	check(c.RPC.DefaultTimeout > 0 && c.RPC.MaxTimeout > 0, "rpc timeouts must be positive")
+	check(c.RPC.DefaultTimeout <= c.RPC.MaxTimeout,
+		"rpc.default_timeout (%s) cannot exceed rpc.max_timeout (%s)", c.RPC.DefaultTimeout, c.RPC.MaxTimeout)
+
+	check(c.RateLimit.Backend == "memory" || c.RateLimit.Backend == "postgres",
+		"rate_limit.backend: invalid backend %q", c.RateLimit.Backend)
+	if _, err := ratelimit.ParseLimits(c.RateLimit.Limits); err != nil {
+		errs = append(errs, fmt.Errorf("rate_limit.limits: %w", err))
+	}
+	if c.RateLimit.IPLimit != "" {
+		if _, err := ratelimit.ParseLimit(c.RateLimit.IPLimit); err != nil {
+			errs = append(errs, fmt.Errorf("rate_limit.ip_limit: %w", err))
+		}
+	}
+
+	check(c.CORS.MaxAge >= 0, "cors.max_age cannot be negative")
+
+	return errors.Join(errs...)
+}
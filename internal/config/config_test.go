@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateJWTSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr string
+	}{
+		{"random secret", testJWTSecret, ""},
+		{"example value", "change-me", "at least 32 bytes"},
+		{"short secret", "0123456789abcdef", "at least 32 bytes"},
+		{"long placeholder", "please-change-me-before-deploying-this", "placeholder"},
+		{"long placeholder uppercase", "YOUR-SECRET-GOES-HERE-0123456789abcdef", "placeholder"},
+		{"no secret", "", "auth: set auth.jwt_secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Auth.JWTSecret = tt.secret
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestExampleConfigRequiresSecret checks that the example file cannot be
+// deployed as is with a guessable key.
+func TestExampleConfigRequiresSecret(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("JWT_SECRET", "")
+
+	_, err := Load("test", []string{"-config", "../../configs/server.example.yaml"})
+	if err == nil || !strings.Contains(err.Error(), "auth.jwt_secret") {
+		t.Fatalf("Load of the example error = %v, want it to require auth.jwt_secret", err)
+	}
+
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	if _, err := Load("test", []string{"-config", "../../configs/server.example.yaml"}); err != nil {
+		t.Errorf("Load of the example with JWT_SECRET error = %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ConnString returns the DSN, or builds one from the discrete fields.
+func (d Database) ConnString() string {
+	if d.DSN != "" {
+		return d.DSN
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		Host:     net.JoinHostPort(d.Host, d.Port),
+		Path:     "/" + d.Name,
+		RawQuery: url.Values{"sslmode": {d.SSLMode}}.Encode(),
+	}
+	if d.User != "" {
+		u.User = url.UserPassword(d.User, d.Password)
+	}
+	return u.String()
+}
+
+// PoolConfig parses the connection string and applies the pool settings
+// and the statement and lock timeouts.
+func (d Database) PoolConfig() (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(d.ConnString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	if d.MaxConns > 0 {
+		poolConfig.MaxConns = d.MaxConns
+	}
+	if d.MinConns > 0 {
+		poolConfig.MinConns = d.MinConns
+	}
+	if d.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = d.MaxConnLifetime
+	}
+	if d.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = d.MaxConnIdleTime
+	}
+	if d.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = d.HealthCheckPeriod
+	}
+	if d.ConnectTimeout > 0 {
+		poolConfig.ConnConfig.ConnectTimeout = d.ConnectTimeout
+	}
+	if d.StatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(d.StatementTimeout.Milliseconds(), 10)
+	}
+	if d.LockTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["lock_timeout"] = strconv.FormatInt(d.LockTimeout.Milliseconds(), 10)
+	}
+
+	return poolConfig, nil
+}
+
+// RetryPolicy returns the retry settings for repository statements.
+func (d Database) RetryPolicy() database.RetryPolicy {
+	return database.RetryPolicy{
+		MaxAttempts: d.RetryAttempts,
+		BaseDelay:   d.RetryBaseDelay,
+		MaxDelay:    d.RetryMaxDelay,
+	}
+}
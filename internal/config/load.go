@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+// Load builds the configuration from the command-line arguments (without
+// the program name). The file is taken from -config or CONFIG_FILE.
+func Load(name string, args []string) (*Config, error) {
+	cfg := Default()
+	fields := leafFields(cfg)
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env CONFIG_FILE)")
+
+	// Los flags se aplican después del archivo y del entorno
+	var overrides []func() error
+	for _, f := range fields {
+		usage := fmt.Sprintf("%s (env %s)", f.path, f.env)
+		set := func(raw string) error {
+			overrides = append(overrides, func() error { return f.set(raw) })
+			return nil
+		}
+		if f.value.Kind() == reflect.Bool {
+			fs.BoolFunc(f.flagName(), usage, set)
+		} else {
+			fs.Func(f.flagName(), usage, set)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	if *configFile != "" {
+		if err := loadFile(*configFile, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		if raw := os.Getenv(f.env); raw != "" {
+			if err := f.set(raw); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", f.env, err)
+			}
+		}
+	}
+
+	for _, override := range overrides {
+		if err := override(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// loadFile decodes a YAML or TOML file, chosen by extension, over cfg.
+// Unknown keys are rejected so that typos do not go unnoticed.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("failed to parse %s: unknown key %q", path, undecoded[0].String())
+		}
+	default:
+		return fmt.Errorf("unsupported config file format %q (use .yaml, .yml or .toml)", filepath.Ext(path))
+	}
+	return nil
+}
+
+// field is a leaf of the Config tree.
+type field struct {
+	path   string // clave en el archivo, p. ej. database.max_conns
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.path, "_", "-")
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses raw into the field using the same syntax for env and flags.
+func (f field) set(raw string) error {
+	v := f.value
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+		v.SetInt(n)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s: unsupported type %s", f.path, v.Type())
+	}
+	return nil
+}
+
+// leafFields walks cfg and returns every settable leaf field.
+func leafFields(cfg *Config) []field {
+	var fields []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			path := sf.Tag.Get("yaml")
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			if sf.Type.Kind() == reflect.Struct {
+				walk(v.Field(i), path)
+				continue
+			}
+			fields = append(fields, field{
+				path:   path,
+				env:    sf.Tag.Get("env"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return fields
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+// writeConfigFile writes content to a file named name in a temporary
+// directory and returns its path.
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	yamlFile := writeConfigFile(t, "server.yaml", `
+server:
+  port: "1111"
+log:
+  level: warn
+rpc:
+  default_timeout: 5s
+cors:
+  allowed_origins: [https://file.example.com]
+`)
+	tomlFile := writeConfigFile(t, "server.toml", `
+[server]
+port = "1111"
+
+[log]
+level = "warn"
+`)
+
+	type want struct {
+		port    string
+		level   string
+		timeout time.Duration
+		origins []string
+	}
+	defaults := want{port: "50051", level: "info", timeout: 10 * time.Second}
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want want
+	}{
+		{
+			name: "defaults",
+			want: defaults,
+		},
+		{
+			name: "file over defaults",
+			args: []string{"-config", yamlFile},
+			want: want{port: "1111", level: "warn", timeout: 5 * time.Second, origins: []string{"https://file.example.com"}},
+		},
+		{
+			name: "toml file",
+			args: []string{"-config", tomlFile},
+			want: want{port: "1111", level: "warn", timeout: 10 * time.Second},
+		},
+		{
+			name: "file from CONFIG_FILE",
+			env:  map[string]string{"CONFIG_FILE": tomlFile},
+			want: want{port: "1111", level: "warn", timeout: 10 * time.Second},
+		},
+		{
+			name: "-config over CONFIG_FILE",
+			env:  map[string]string{"CONFIG_FILE": tomlFile},
+			args: []string{"-config", yamlFile},
+			want: want{port: "1111", level: "warn", timeout: 5 * time.Second, origins: []string{"https://file.example.com"}},
+		},
+		{
+			name: "env over file",
+			env:  map[string]string{"PORT": "2222", "CORS_ALLOWED_ORIGINS": "https://a.example.com, https://b.example.com"},
+			args: []string{"-config", yamlFile},
+			want: want{port: "2222", level: "warn", timeout: 5 * time.Second, origins: []string{"https://a.example.com", "https://b.example.com"}},
+		},
+		{
+			name: "flags over env and file",
+			env:  map[string]string{"PORT": "2222", "RPC_DEFAULT_TIMEOUT": "7s"},
+			args: []string{"-config", yamlFile, "-server.port", "3333", "-rpc.default-timeout=3s"},
+			want: want{port: "3333", level: "warn", timeout: 3 * time.Second, origins: []string{"https://file.example.com"}},
+		},
+		{
+			name: "empty env does not override",
+			env:  map[string]string{"PORT": ""},
+			args: []string{"-config", yamlFile},
+			want: want{port: "1111", level: "warn", timeout: 5 * time.Second, origins: []string{"https://file.example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", "")
+			t.Setenv("JWT_SECRET", testJWTSecret)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load("test", tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := want{port: cfg.Server.Port, level: cfg.Log.Level, timeout: cfg.RPC.DefaultTimeout, origins: cfg.CORS.AllowedOrigins}
+			if got.port != tt.want.port || got.level != tt.want.level || got.timeout != tt.want.timeout || !slices.Equal(got.origins, tt.want.origins) {
+				t.Errorf("Load = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadBoolFlag(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("AUTH_DISABLED", "false")
+
+	// Un flag booleano sin valor vale true y gana al entorno
+	cfg, err := Load("test", []string{"-auth.disabled"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Auth.Disabled {
+		t.Error("-auth.disabled did not override AUTH_DISABLED=false")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "unknown yaml key",
+			args:    []string{"-config", writeConfigFile(t, "typo.yaml", "server:\n  prot: \"1\"\n")},
+			wantErr: "prot",
+		},
+		{
+			name:    "unknown toml key",
+			args:    []string{"-config", writeConfigFile(t, "typo.toml", "[server]\nprot = \"1\"\n")},
+			wantErr: "server.prot",
+		},
+		{
+			name:    "unsupported extension",
+			args:    []string{"-config", writeConfigFile(t, "server.json", "{}")},
+			wantErr: "unsupported config file format",
+		},
+		{
+			name:    "invalid env value",
+			env:     map[string]string{"RPC_DEFAULT_TIMEOUT": "soon"},
+			wantErr: "RPC_DEFAULT_TIMEOUT",
+		},
+		{
+			name:    "invalid flag value",
+			args:    []string{"-database.max-conns", "many"},
+			wantErr: "database.max_conns",
+		},
+		{
+			name:    "unexpected argument",
+			args:    []string{"serve"},
+			wantErr: "unexpected arguments",
+		},
+		{
+			name:    "invalid result",
+			args:    []string{"-server.port", "70000"},
+			wantErr: "server.port",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", "")
+			t.Setenv("JWT_SECRET", testJWTSecret)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := Load("test", tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
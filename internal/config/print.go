@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const redacted = "REDACTED"
+
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// Redacted returns a copy of the configuration with every secret hidden.
+func (c *Config) Redacted() *Config {
+	clone := *c
+	for _, f := range leafFields(&clone) {
+		if !f.secret || f.value.String() == "" {
+			continue
+		}
+		if f.path == "database.dsn" {
+			f.value.SetString(redactDSN(f.value.String()))
+			continue
+		}
+		f.value.SetString(redacted)
+	}
+	return &clone
+}
+
+// redactDSN hides the password of a URL or keyword/value connection string.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		return u.String()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
+
+// Print writes the effective configuration as YAML with secrets redacted.
+func Print(w io.Writer, c *Config) error {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
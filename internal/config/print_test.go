@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		dsn     string
+		wantDSN string
+	}{
+		{"no dsn", "", ""},
+		{"url dsn", "postgres://app:s3cr3t@db:5432/tasks?sslmode=disable", "postgres://app:REDACTED@db:5432/tasks?sslmode=disable"},
+		{"url dsn without password", "postgres://app@db:5432/tasks", "postgres://app@db:5432/tasks"},
+		{"keyword dsn", "host=db user=app password=s3cr3t dbname=tasks", "host=db user=app password=REDACTED dbname=tasks"},
+		{"keyword dsn quoted", "host=db password = 'with spaces' dbname=tasks", "host=db password = REDACTED dbname=tasks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Database.DSN = tt.dsn
+			cfg.Database.User = "app"
+			cfg.Database.Password = "s3cr3t"
+			cfg.Auth.JWTSecret = testJWTSecret
+
+			var out bytes.Buffer
+			if err := Print(&out, cfg); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(out.String(), "s3cr3t") || strings.Contains(out.String(), testJWTSecret) || strings.Contains(out.String(), "with spaces") {
+				t.Fatalf("printed configuration leaks a secret:\n%s", out.String())
+			}
+
+			printed := &Config{}
+			if err := yaml.Unmarshal(out.Bytes(), printed); err != nil {
+				t.Fatal(err)
+			}
+			if printed.Database.DSN != tt.wantDSN {
+				t.Errorf("database.dsn = %q, want %q", printed.Database.DSN, tt.wantDSN)
+			}
+			if printed.Database.Password != redacted || printed.Auth.JWTSecret != redacted {
+				t.Errorf("password = %q, jwt_secret = %q, want %q", printed.Database.Password, printed.Auth.JWTSecret, redacted)
+			}
+			// Los campos que no son secretos se muestran tal cual
+			if printed.Database.User != "app" || printed.Server.Port != cfg.Server.Port {
+				t.Errorf("user = %q, port = %q, want app, %s", printed.Database.User, printed.Server.Port, cfg.Server.Port)
+			}
+
+			if cfg.Database.Password != "s3cr3t" || cfg.Auth.JWTSecret != testJWTSecret || cfg.Database.DSN != tt.dsn {
+				t.Error("Print modified the configuration")
+			}
+		})
+	}
+}
+
+func TestRedactedKeepsEmptySecrets(t *testing.T) {
+	redactedCfg := Default().Redacted()
+	if redactedCfg.Database.Password != "" || redactedCfg.Auth.JWTSecret != "" {
+		t.Errorf("empty secrets were redacted: password = %q, jwt_secret = %q", redactedCfg.Database.Password, redactedCfg.Auth.JWTSecret)
+	}
+}
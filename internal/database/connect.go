@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Connect creates the pool and waits until the database answers a ping,
+// retrying with exponential backoff for up to timeout. A zero timeout
+// tries only once.
+func Connect(ctx context.Context, poolConfig *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	backoff := RetryPolicy{BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+	for attempt := 0; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err = pool.Ping(pingCtx)
+		cancel()
+		if err == nil {
+			return pool, nil
+		}
+
+		delay := backoff.backoff(attempt)
+		if ctx.Err() != nil || time.Now().Add(delay).After(deadline) {
+			pool.Close()
+			return nil, fmt.Errorf("database unreachable after %d attempts: %w", attempt+1, err)
+		}
+
+		slog.Warn("Database not ready, retrying", "attempt", attempt+1, "retry_in", delay.Round(time.Millisecond), "error", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+	}
+}
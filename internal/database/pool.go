@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Querier is the subset of *pgxpool.Pool used by the repositories.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// RetryingPool retries each statement on transient errors. Errors raised
+// while iterating rows are not retried, since part of the result may
+// already have been consumed.
+type RetryingPool struct {
+	pool   *pgxpool.Pool
+	policy RetryPolicy
+}
+
+func NewRetryingPool(pool *pgxpool.Pool, policy RetryPolicy) *RetryingPool {
+	return &RetryingPool{
+		pool:   pool,
+		policy: policy,
+	}
+}
+
+func (p *RetryingPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	var tag pgconn.CommandTag
+	err := p.policy.Do(ctx, func() error {
+		var err error
+		tag, err = p.pool.Exec(ctx, sql, args...)
+		return err
+	})
+	return tag, err
+}
+
+func (p *RetryingPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	var rows pgx.Rows
+	err := p.policy.Do(ctx, func() error {
+		var err error
+		rows, err = p.pool.Query(ctx, sql, args...)
+		return err
+	})
+	return rows, err
+}
+
+// Begin starts a transaction. Only the BEGIN is retried: the statements of
+// the transaction run on its connection without retries.
+func (p *RetryingPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	var tx pgx.Tx
+	err := p.policy.Do(ctx, func() error {
+		var err error
+		tx, err = p.pool.Begin(ctx)
+		return err
+	})
+	return tx, err
+}
+
+func (p *RetryingPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &retryingRow{pool: p, ctx: ctx, sql: sql, args: args}
+}
+
+// retryingRow defers the query to Scan, where pgx reports its errors.
+type retryingRow struct {
+	pool *RetryingPool
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (r *retryingRow) Scan(dest ...any) error {
+	return r.pool.policy.Do(r.ctx, func() error {
+		return r.pool.pool.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	})
+}
@@ -0,0 +1,92 @@
+// Package database wraps the pgx pool with startup retries and bounded
+// retries of statements that fail with transient errors.
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RetryPolicy bounds the retries of a statement. Delays grow exponentially
+// from BaseDelay up to MaxDelay, with full jitter.
+type RetryPolicy struct {
+	MaxAttempts int // incluye el primer intento; 1 desactiva los reintentos
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay) + 1
+}
+
+// Do runs fn until it succeeds, fails with a non-transient error, the
+// attempts are exhausted or ctx is done.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = fn(); err == nil || !IsTransient(err) || attempt+1 >= p.MaxAttempts {
+			return err
+		}
+
+		delay := p.backoff(attempt)
+		slog.DebugContext(ctx, "Retrying statement after transient error", "attempt", attempt+1, "retry_in", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// SQLSTATE codes that guarantee the statement did not take effect.
+var transientCodes = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"55P03": true, // lock_not_available
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+	"53300": true, // too_many_connections
+}
+
+// IsTransient reports whether err is safe to retry: the server rejected
+// the statement (serialization failures, deadlocks, restarts) or it never
+// reached the server. A connection lost after sending the statement is not
+// retried, since the statement may have been committed and repeating an
+// INSERT would fail with a unique violation for a row that was created.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Clase 08: excepciones de conexión
+		return transientCodes[pgErr.Code] || strings.HasPrefix(pgErr.Code, "08")
+	}
+
+	// SafeToRetry garantiza que no se envió nada al servidor
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || errors.Is(err, syscall.ECONNREFUSED)
+}
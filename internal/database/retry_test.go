@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// netError mimics the pgconn errors that report whether the statement was
+// sent to the server.
+type netError struct {
+	err         error
+	safeToRetry bool
+}
+
+func (e *netError) Error() string     { return e.err.Error() }
+func (e *netError) Unwrap() error     { return e.err }
+func (e *netError) SafeToRetry() bool { return e.safeToRetry }
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, true},
+		{"too many connections", &pgconn.PgError{Code: "53300"}, true},
+		{"connection exception", &pgconn.PgError{Code: "08006"}, true},
+		{"connection does not exist", &pgconn.PgError{Code: "08003"}, true},
+		{"wrapped serialization failure", fmt.Errorf("failed to update task: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"syntax error", &pgconn.PgError{Code: "42601"}, false},
+		{"empty code", &pgconn.PgError{}, false},
+		{"not sent", &netError{err: io.ErrUnexpectedEOF, safeToRetry: true}, true},
+		// La sentencia pudo aplicarse antes de perder la conexión
+		{"lost after send", &netError{err: io.ErrUnexpectedEOF, safeToRetry: false}, false},
+		{"connection refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), true},
+		{"canceled", context.Canceled, false},
+		{"deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), false},
+		{"canceled but safe to retry", &netError{err: context.Canceled, safeToRetry: true}, false},
+		{"other error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.want {
+				t.Errorf("IsTransient(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	transient := &pgconn.PgError{Code: "40001"}
+	permanent := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name         string
+		maxAttempts  int
+		errs         []error // error de cada intento; nil a partir del último
+		wantAttempts int
+		wantErr      error
+	}{
+		{"success", 3, nil, 1, nil},
+		{"transient then success", 3, []error{transient, transient}, 3, nil},
+		{"attempts are bounded", 3, []error{transient, transient, transient, transient}, 3, transient},
+		{"permanent is not retried", 3, []error{permanent}, 1, permanent},
+		{"transient then permanent", 3, []error{transient, permanent}, 2, permanent},
+		{"single attempt", 1, []error{transient}, 1, transient},
+		{"zero attempts still runs once", 0, []error{transient}, 1, transient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := RetryPolicy{MaxAttempts: tt.maxAttempts}
+			attempts := 0
+			err := policy.Do(context.Background(), func() error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDoStopsWhenContextIsDone(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := policy.Do(ctx, func() error {
+		attempts++
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if attempts != 1 || err == nil {
+		t.Errorf("attempts = %d, error = %v; want 1 attempt and the last error", attempts, err)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for attempt, limit := range []time.Duration{10, 20, 40, 50, 50, 50} {
+		limit *= time.Millisecond
+		for range 20 {
+			if delay := policy.backoff(attempt); delay <= 0 || delay > limit {
+				t.Fatalf("backoff(%d) = %s, want (0, %s]", attempt, delay, limit)
+			}
+		}
+	}
+	// Un desplazamiento enorme no debe desbordar el retardo
+	if delay := policy.backoff(100); delay <= 0 || delay > policy.MaxDelay {
+		t.Errorf("backoff(100) = %s, want (0, %s]", delay, policy.MaxDelay)
+	}
+}
+
+// TestRetryingPool runs against the database in TEST_DATABASE_URL. The
+// statements call a function that bumps a sequence, which is not rolled
+// back, before failing, so the sequence counts the attempts.
+func TestRetryingPool(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	const setup = `
+		DROP SEQUENCE IF EXISTS retry_test_attempts;
+		CREATE SEQUENCE retry_test_attempts;
+		CREATE OR REPLACE FUNCTION retry_test_fail(code TEXT) RETURNS INT AS $$
+		BEGIN
+			PERFORM nextval('retry_test_attempts');
+			RAISE EXCEPTION 'test failure' USING ERRCODE = code;
+		END $$ LANGUAGE plpgsql;`
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DROP FUNCTION IF EXISTS retry_test_fail(TEXT); DROP SEQUENCE IF EXISTS retry_test_attempts;")
+	})
+
+	retrying := NewRetryingPool(pool, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+	const failing = "SELECT retry_test_fail($1);"
+	exec := func(code string) error { _, err := retrying.Exec(ctx, failing, code); return err }
+	queryRow := func(code string) error { return retrying.QueryRow(ctx, failing, code).Scan(new(int)) }
+
+	tests := []struct {
+		name         string
+		run          func(code string) error
+		code         string
+		wantAttempts int64
+	}{
+		{"exec serialization failure", exec, "40001", 3},
+		{"exec deadlock", exec, "40P01", 3},
+		{"exec unique violation", exec, "23505", 1},
+		{"query row serialization failure", queryRow, "40001", 3},
+		{"query row unique violation", queryRow, "23505", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pool.Exec(ctx, setup); err != nil {
+				t.Fatal(err)
+			}
+
+			err := tt.run(tt.code)
+			var pgErr *pgconn.PgError
+			if !errors.As(err, &pgErr) || pgErr.Code != tt.code {
+				t.Fatalf("error = %v, want SQLSTATE %s", err, tt.code)
+			}
+
+			var attempts int64
+			const countAttempts = "SELECT CASE WHEN is_called THEN last_value ELSE 0 END FROM retry_test_attempts;"
+			if err := pool.QueryRow(ctx, countAttempts).Scan(&attempts); err != nil {
+				t.Fatal(err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
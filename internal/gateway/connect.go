@@ -0,0 +1,203 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/logging"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Browsers call the services with gRPC-Web or the Connect protocol through
+// connect-go handlers. Each handler proxies the call to the gRPC server so
+// authentication, rate limiting and logging behave exactly as over gRPC.
+
+// connectForwardedHeaders are copied from the HTTP request into the
+// outgoing gRPC metadata.
+var connectForwardedHeaders = []string{"Authorization", "X-Api-Key", "X-Request-Id"}
+
+// message constrains a type parameter to a pointer to a generated message.
+type message[T any] interface {
+	*T
+	proto.Message
+}
+
+// registerConnect mounts a handler for every TaskService, ApiKeyService and
+// CalendarService method on its gRPC path.
+func registerConnect(mux *http.ServeMux, conn *grpc.ClientConn) {
+	mux.Handle(unaryProxy[taskpb.CreateTaskRequest, taskpb.CreateTaskResponse](conn, taskpb.TaskService_CreateTask_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.GetTaskRequest, taskpb.GetTaskResponse](conn, taskpb.TaskService_GetTask_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.UpdateTaskRequest, taskpb.UpdateTaskResponse](conn, taskpb.TaskService_UpdateTask_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.DeleteTaskRequest, taskpb.DeleteTaskResponse](conn, taskpb.TaskService_DeleteTask_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.MarkTaskCompleteRequest, taskpb.MarkTaskCompleteResponse](conn, taskpb.TaskService_MarkTaskComplete_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.ListTasksByUserRequest, taskpb.ListTasksResponse](conn, taskpb.TaskService_ListTasksByUser_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.ListAllTasksRequest, taskpb.ListTasksResponse](conn, taskpb.TaskService_ListAllTasks_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.ShareTaskRequest, taskpb.ShareTaskResponse](conn, taskpb.TaskService_ShareTask_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.UnshareTaskRequest, taskpb.UnshareTaskResponse](conn, taskpb.TaskService_UnshareTask_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.ListCollaboratorsRequest, taskpb.ListCollaboratorsResponse](conn, taskpb.TaskService_ListCollaborators_FullMethodName))
+	mux.Handle(serverStreamProxy[taskpb.ExportTasksRequest, taskpb.ExportTasksResponse](conn, taskpb.TaskService_ExportTasks_FullMethodName))
+	mux.Handle(clientStreamProxy[taskpb.ImportTasksRequest, taskpb.ImportTasksResponse](conn, taskpb.TaskService_ImportTasks_FullMethodName))
+	mux.Handle(serverStreamProxy[taskpb.CompleteTasksMatchingRequest, taskpb.TasksMatchingProgress](conn, taskpb.TaskService_CompleteTasksMatching_FullMethodName))
+	mux.Handle(serverStreamProxy[taskpb.DeleteTasksMatchingRequest, taskpb.TasksMatchingProgress](conn, taskpb.TaskService_DeleteTasksMatching_FullMethodName))
+
+	mux.Handle(unaryProxy[taskpb.CreateApiKeyRequest, taskpb.CreateApiKeyResponse](conn, taskpb.ApiKeyService_CreateApiKey_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.ListApiKeysRequest, taskpb.ListApiKeysResponse](conn, taskpb.ApiKeyService_ListApiKeys_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.RotateApiKeyRequest, taskpb.RotateApiKeyResponse](conn, taskpb.ApiKeyService_RotateApiKey_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.RevokeApiKeyRequest, taskpb.RevokeApiKeyResponse](conn, taskpb.ApiKeyService_RevokeApiKey_FullMethodName))
+
+	mux.Handle(unaryProxy[taskpb.CreateCalendarFeedRequest, taskpb.CreateCalendarFeedResponse](conn, taskpb.CalendarService_CreateCalendarFeed_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.ListCalendarFeedsRequest, taskpb.ListCalendarFeedsResponse](conn, taskpb.CalendarService_ListCalendarFeeds_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.RevokeCalendarFeedRequest, taskpb.RevokeCalendarFeedResponse](conn, taskpb.CalendarService_RevokeCalendarFeed_FullMethodName))
+	mux.Handle(unaryProxy[taskpb.ImportCalendarRequest, taskpb.ImportTasksResponse](conn, taskpb.CalendarService_ImportCalendar_FullMethodName))
+}
+
+// outgoingContext copies the credentials and request ID of the HTTP request
+// into the gRPC metadata, plus the client address for the forwarded peer.
+func outgoingContext(ctx context.Context, header http.Header, peer connect.Peer) context.Context {
+	md := metadata.MD{}
+	for _, key := range connectForwardedHeaders {
+		if values := header.Values(key); len(values) > 0 {
+			md.Set(key, values...)
+		}
+	}
+	if host, _, err := net.SplitHostPort(peer.Addr); err == nil {
+		md.Set("x-forwarded-for", host)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// connectError converts a gRPC status into a Connect error, keeping its
+// details and the request ID.
+func connectError(err error, header metadata.MD) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return connect.NewError(connect.CodeUnknown, err)
+	}
+
+	connectErr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, detail := range st.Details() {
+		if msg, ok := detail.(proto.Message); ok {
+			if d, err := connect.NewErrorDetail(msg); err == nil {
+				connectErr.AddDetail(d)
+			}
+		}
+	}
+	setRequestID(connectErr.Meta(), header)
+	return connectErr
+}
+
+func setRequestID(h http.Header, md metadata.MD) {
+	if ids := md.Get(logging.RequestIDKey); len(ids) > 0 {
+		h.Set("X-Request-Id", ids[0])
+	}
+}
+
+// unaryProxy returns the path and handler of a unary RPC, like the
+// constructors generated by protoc-gen-connect-go.
+func unaryProxy[Req, Res any, PReq message[Req], PRes message[Res]](conn *grpc.ClientConn, procedure string) (string, http.Handler) {
+	return procedure, connect.NewUnaryHandler(procedure,
+		func(ctx context.Context, req *connect.Request[Req]) (*connect.Response[Res], error) {
+			ctx = outgoingContext(ctx, req.Header(), req.Peer())
+
+			var header metadata.MD
+			res := new(Res)
+			if err := conn.Invoke(ctx, procedure, PReq(req.Msg), PRes(res), grpc.Header(&header)); err != nil {
+				return nil, connectError(err, header)
+			}
+
+			response := connect.NewResponse(res)
+			setRequestID(response.Header(), header)
+			return response, nil
+		},
+	)
+}
+
+// serverStreamProxy forwards a server-streaming RPC, relaying every message
+// to the browser as soon as the gRPC server sends it.
+func serverStreamProxy[Req, Res any, PReq message[Req], PRes message[Res]](conn *grpc.ClientConn, procedure string) (string, http.Handler) {
+	return procedure, connect.NewServerStreamHandler(procedure,
+		func(ctx context.Context, req *connect.Request[Req], out *connect.ServerStream[Res]) error {
+			ctx = outgoingContext(ctx, req.Header(), req.Peer())
+
+			desc := &grpc.StreamDesc{StreamName: procedure, ServerStreams: true}
+			stream, err := conn.NewStream(ctx, desc, procedure)
+			if err != nil {
+				return connectError(err, nil)
+			}
+			if err := stream.SendMsg(PReq(req.Msg)); err != nil {
+				return connectError(err, nil)
+			}
+			if err := stream.CloseSend(); err != nil {
+				return connectError(err, nil)
+			}
+
+			header, err := stream.Header()
+			if err != nil {
+				return connectError(err, header)
+			}
+			setRequestID(out.ResponseHeader(), header)
+
+			for {
+				res := new(Res)
+				if err := stream.RecvMsg(PRes(res)); err != nil {
+					if errors.Is(err, io.EOF) {
+						return nil
+					}
+					return connectError(err, header)
+				}
+				if err := out.Send(res); err != nil {
+					return err
+				}
+			}
+		},
+	)
+}
+
+// clientStreamProxy forwards a client-streaming RPC. Browsers cannot
+// stream request bodies, so it is only reachable from Connect clients
+// running over HTTP/2.
+func clientStreamProxy[Req, Res any, PReq message[Req], PRes message[Res]](conn *grpc.ClientConn, procedure string) (string, http.Handler) {
+	return procedure, connect.NewClientStreamHandler(procedure,
+		func(ctx context.Context, in *connect.ClientStream[Req]) (*connect.Response[Res], error) {
+			ctx = outgoingContext(ctx, in.RequestHeader(), in.Peer())
+
+			desc := &grpc.StreamDesc{StreamName: procedure, ClientStreams: true}
+			stream, err := conn.NewStream(ctx, desc, procedure)
+			if err != nil {
+				return nil, connectError(err, nil)
+			}
+
+			for in.Receive() {
+				if err := stream.SendMsg(PReq(in.Msg())); err != nil {
+					// El error real llega con RecvMsg
+					break
+				}
+			}
+			if err := in.Err(); err != nil {
+				return nil, err
+			}
+			if err := stream.CloseSend(); err != nil {
+				return nil, connectError(err, nil)
+			}
+
+			res := new(Res)
+			err = stream.RecvMsg(PRes(res))
+			header, _ := stream.Header()
+			if err != nil {
+				return nil, connectError(err, header)
+			}
+
+			response := connect.NewResponse(res)
+			setRequestID(response.Header(), header)
+			return response, nil
+		},
+	)
+}
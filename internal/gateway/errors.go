@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/logging"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var errorMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// errorHandler writes every gateway error as a google.rpc.Status JSON body,
+// the same schema documented in the OpenAPI specs.
+func errorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		if ids := md.HeaderMD.Get(logging.RequestIDKey); len(ids) > 0 {
+			w.Header().Set("X-Request-Id", ids[0])
+		}
+	}
+
+	var httpErr *runtime.HTTPStatusError
+	if e, ok := err.(*runtime.HTTPStatusError); ok {
+		httpErr = e
+		err = e.Err
+	}
+
+	st := status.Convert(err)
+	code := runtime.HTTPStatusFromCode(st.Code())
+	if httpErr != nil {
+		code = httpErr.HTTPStatus
+	}
+
+	for _, detail := range st.Details() {
+		if retry, ok := detail.(*errdetails.RetryInfo); ok {
+			seconds := math.Ceil(retry.GetRetryDelay().AsDuration().Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+		}
+	}
+
+	writeStatus(w, code, st)
+}
+
+// writeError writes an error produced outside of the gRPC handlers (CORS,
+// unknown routes) with the same body as errorHandler.
+func writeError(w http.ResponseWriter, httpStatus int, code codes.Code, msg string) {
+	writeStatus(w, httpStatus, status.New(code, msg))
+}
+
+func writeStatus(w http.ResponseWriter, httpStatus int, st *status.Status) {
+	body, err := errorMarshaler.Marshal(st.Proto())
+	if err != nil {
+		httpStatus = http.StatusInternalServerError
+		body = []byte(`{"code":13,"message":"failed to marshal error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if st.Code() == codes.Unauthenticated {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	w.WriteHeader(httpStatus)
+	w.Write(body)
+}
@@ -0,0 +1,93 @@
+// Package gateway serves the gRPC services to HTTP clients: a REST/JSON API
+// generated by grpc-gateway from the google.api.http annotations, and the
+// gRPC-Web and Connect protocols for browsers.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/api"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/logging"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// forwardedHeaders are the HTTP headers passed to the gRPC server as
+// metadata besides the ones grpc-gateway forwards by default.
+var forwardedHeaders = map[string]bool{
+	"X-Api-Key":    true,
+	"X-Request-Id": true,
+}
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	if forwardedHeaders[textproto.CanonicalMIMEHeaderKey(key)] {
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher only exposes the request ID from the response
+// metadata; the rest stays internal to gRPC.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == logging.RequestIDKey {
+		return "X-Request-Id", true
+	}
+	return "", false
+}
+
+// NewServer returns an HTTP server that translates REST, gRPC-Web and
+// Connect calls into RPCs on conn and publishes the OpenAPI specs under
+// /openapi.
+func NewServer(ctx context.Context, addr string, conn *grpc.ClientConn, corsCfg CORSConfig) (*http.Server, error) {
+	gwmux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithHealthzEndpoint(healthpb.NewHealthClient(conn)),
+	)
+
+	if err := taskpb.RegisterTaskServiceHandler(ctx, gwmux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register task service gateway: %w", err)
+	}
+	if err := taskpb.RegisterApiKeyServiceHandler(ctx, gwmux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register api key service gateway: %w", err)
+	}
+	if err := taskpb.RegisterCalendarServiceHandler(ctx, gwmux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register calendar service gateway: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi/v2.json", serveSpec("application/json", api.OpenAPIv2))
+	mux.HandleFunc("GET /openapi/v3.yaml", serveSpec("application/yaml", api.OpenAPIv3))
+	registerConnect(mux, conn)
+	mux.Handle("/", gwmux)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           cors(corsCfg, mux),
+		ReadHeaderTimeout: 5 * time.Second,
+	}, nil
+}
+
+func serveSpec(contentType string, spec []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write(spec)
+	}
+}
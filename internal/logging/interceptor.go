@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Interceptor assigns a request ID to every RPC, logs its outcome and adds
+// the ID to the error messages returned to clients.
+type Interceptor struct {
+	logger *slog.Logger
+	redact bool // oculta las descripciones de las tareas en los payloads
+}
+
+func NewInterceptor(logger *slog.Logger, redactDescriptions bool) *Interceptor {
+	return &Interceptor{
+		logger: logger,
+		redact: redactDescriptions,
+	}
+}
+
+// start resolves the request ID and stores it in the context.
+func (i *Interceptor) start(ctx context.Context) (context.Context, string) {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDKey); len(values) > 0 {
+			incoming = values[0]
+		}
+	}
+
+	id := newRequestID(incoming)
+	return WithRequestID(ctx, id), id
+}
+
+func (i *Interceptor) finish(ctx context.Context, method, id string, start time.Time, req, resp any, err error) error {
+	code := status.Code(err)
+	attrs := []any{
+		slog.String("request_id", id),
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+
+	if err != nil {
+		i.logger.WarnContext(ctx, "rpc failed", append(attrs, slog.String("error", status.Convert(err).Message()))...)
+		return withRequestID(err, id)
+	}
+
+	if req != nil && i.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs,
+			slog.Any("request", payload{msg: req, redact: i.redact}),
+			slog.Any("response", payload{msg: resp, redact: i.redact}),
+		)
+	}
+	i.logger.InfoContext(ctx, "rpc completed", attrs...)
+
+	return nil
+}
+
+// withRequestID appends the request ID to the status message, keeping the
+// code and details of the original error.
+func withRequestID(err error, id string) error {
+	st := status.Convert(err).Proto()
+	st.Message = fmt.Sprintf("%s (request_id=%s)", st.Message, id)
+	return status.FromProto(st).Err()
+}
+
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, id := i.start(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+
+		resp, err := handler(ctx, req)
+		return resp, i.finish(ctx, info.FullMethod, id, start, req, resp, err)
+	}
+}
+
+func (i *Interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, id := i.start(ss.Context())
+		ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		return i.finish(ctx, info.FullMethod, id, start, nil, nil, err)
+	}
+}
+
+// contextStream overrides the context of a grpc.ServerStream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
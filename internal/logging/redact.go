@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"log/slog"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// redactedFields are cleared from messages before they are logged.
+var redactedFields = map[protoreflect.Name]bool{
+	"description": true,
+}
+
+const redactedValue = "[REDACTED]"
+
+// payload renders a message for the logs, redacting sensitive fields.
+type payload struct {
+	msg    any
+	redact bool
+}
+
+func (p payload) LogValue() slog.Value {
+	msg, ok := p.msg.(proto.Message)
+	if !ok {
+		return slog.AnyValue(p.msg)
+	}
+
+	if p.redact {
+		msg = proto.Clone(msg)
+		redactMessage(msg.ProtoReflect())
+	}
+
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return slog.StringValue(err.Error())
+	}
+	return slog.StringValue(string(data))
+}
+
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case redactedFields[fd.Name()] && fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+			m.Set(fd, protoreflect.ValueOfString(redactedValue))
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			redactMessage(v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports pgxpool statistics on every scrape.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	emptyAcquireDuration *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	return &PoolCollector{
+		pool: pool,
+		acquiredConns: prometheus.NewDesc("pgxpool_acquired_conns",
+			"Number of connections currently in use.", nil, nil),
+		idleConns: prometheus.NewDesc("pgxpool_idle_conns",
+			"Number of idle connections in the pool.", nil, nil),
+		totalConns: prometheus.NewDesc("pgxpool_total_conns",
+			"Total number of connections in the pool.", nil, nil),
+		maxConns: prometheus.NewDesc("pgxpool_max_conns",
+			"Maximum size of the pool.", nil, nil),
+		acquireCount: prometheus.NewDesc("pgxpool_acquire_total",
+			"Cumulative count of successful acquires from the pool.", nil, nil),
+		acquireDuration: prometheus.NewDesc("pgxpool_acquire_duration_seconds_total",
+			"Total time spent acquiring connections from the pool.", nil, nil),
+		emptyAcquireCount: prometheus.NewDesc("pgxpool_empty_acquire_total",
+			"Cumulative count of acquires that had to wait for a connection.", nil, nil),
+		emptyAcquireDuration: prometheus.NewDesc("pgxpool_empty_acquire_wait_seconds_total",
+			"Total time spent waiting for a connection when the pool was empty.", nil, nil),
+		canceledAcquireCount: prometheus.NewDesc("pgxpool_canceled_acquire_total",
+			"Cumulative count of acquires cancelled by their context.", nil, nil),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.emptyAcquireDuration
+	ch <- c.canceledAcquireCount
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireDuration, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TaskCollector exports the number of open and completed tasks, queried on
+// every scrape.
+type TaskCollector struct {
+	taskRepo domain.TaskRepository
+	timeout  time.Duration
+	tasks    *prometheus.Desc
+}
+
+func NewTaskCollector(taskRepo domain.TaskRepository) *TaskCollector {
+	return &TaskCollector{
+		taskRepo: taskRepo,
+		timeout:  5 * time.Second,
+		tasks: prometheus.NewDesc("tasks",
+			"Number of tasks stored, by status.", []string{"status"}, nil),
+	}
+}
+
+func (c *TaskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+}
+
+func (c *TaskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	open, completed, err := c.taskRepo.CountTasksByStatus(ctx)
+	if err != nil {
+		slog.Warn("Failed to collect task metrics", "error", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(open), "open")
+	ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(completed), "completed")
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Interceptor rejects RPCs once the caller exhausts its bucket for the method.
+type Interceptor struct {
+	limiter Limiter
+	limits  Limits
+	key     func(ctx context.Context, fullMethod string) string
+	// failOpen allows the requests when the limiter fails, instead of
+	// rejecting them with Unavailable.
+	failOpen bool
+}
+
+// NewInterceptor limits each caller per method. It must run after
+// authentication to tell users apart.
+func NewInterceptor(limiter Limiter, limits Limits, failOpen bool) *Interceptor {
+	return &Interceptor{
+		limiter:  limiter,
+		limits:   limits,
+		key:      func(ctx context.Context, fullMethod string) string { return callerKey(ctx) + "|" + fullMethod },
+		failOpen: failOpen,
+	}
+}
+
+// NewPeerInterceptor limits each client IP address with a single bucket
+// for every method. It runs before authentication, so that failed
+// credentials are limited too.
+func NewPeerInterceptor(limiter Limiter, limit Limit, failOpen bool) *Interceptor {
+	return &Interceptor{
+		limiter:  limiter,
+		limits:   Limits{Default: limit},
+		key:      func(ctx context.Context, _ string) string { return "peer:" + peerKey(ctx) },
+		failOpen: failOpen,
+	}
+}
+
+// callerKey identifies the caller by its principal, or by its IP address
+// when the request is not authenticated.
+func callerKey(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return "user:" + principal.Subject
+	}
+	return "ip:" + peerKey(ctx)
+}
+
+// peerKey returns the IP address of the client.
+func peerKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "anonymous"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return host
+}
+
+func (i *Interceptor) check(ctx context.Context, fullMethod string) error {
+	allowed, wait, err := i.limiter.Allow(ctx, i.key(ctx, fullMethod), i.limits.ForMethod(fullMethod))
+	if err != nil {
+		if i.failOpen {
+			slog.WarnContext(ctx, "Rate limiter unavailable, allowing request", "error", err)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Rate limiter unavailable, rejecting request", "error", err)
+		return status.Error(codes.Unavailable, "rate limiter unavailable, retry later")
+	}
+	if allowed {
+		return nil
+	}
+
+	st := status.Newf(codes.ResourceExhausted, "rate limit exceeded for %s, retry in %s", fullMethod, wait.Round(time.Millisecond))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := i.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
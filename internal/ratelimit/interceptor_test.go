@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// recordingLimiter records the keys it is asked for and allows them all,
+// or fails with err.
+type recordingLimiter struct {
+	keys []string
+	err  error
+}
+
+func (l *recordingLimiter) Allow(_ context.Context, key string, _ Limit) (bool, time.Duration, error) {
+	l.keys = append(l.keys, key)
+	return l.err == nil, 0, l.err
+}
+
+func peerContext(addr string) context.Context {
+	tcpAddr, _ := net.ResolveTCPAddr("tcp", addr)
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr})
+}
+
+func callUnary(i *Interceptor, ctx context.Context, method string) error {
+	_, err := i.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(context.Context, any) (any, error) { return nil, nil })
+	return err
+}
+
+func TestInterceptorKeys(t *testing.T) {
+	ipCtx := peerContext("203.0.113.7:5000")
+	userCtx := auth.NewContext(ipCtx, &auth.Principal{Subject: "alice"})
+	tests := []struct {
+		name   string
+		peer   bool
+		ctx    context.Context
+		method string
+		want   string
+	}{
+		{"user per method", false, userCtx, "/task.TaskService/GetTask", "user:alice|/task.TaskService/GetTask"},
+		{"unauthenticated per method", false, ipCtx, "/task.TaskService/GetTask", "ip:203.0.113.7|/task.TaskService/GetTask"},
+		{"no peer", false, context.Background(), "/task.TaskService/GetTask", "ip:anonymous|/task.TaskService/GetTask"},
+		// Antes de autenticar solo cuenta la IP, con un bucket para todos los métodos
+		{"peer ignores method", true, ipCtx, "/task.TaskService/GetTask", "peer:203.0.113.7"},
+		{"peer ignores principal", true, userCtx, "/task.TaskService/CreateTask", "peer:203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &recordingLimiter{}
+			interceptor := NewInterceptor(limiter, Limits{}, false)
+			if tt.peer {
+				interceptor = NewPeerInterceptor(limiter, Limit{}, false)
+			}
+
+			if err := callUnary(interceptor, tt.ctx, tt.method); err != nil {
+				t.Fatal(err)
+			}
+			if len(limiter.keys) != 1 || limiter.keys[0] != tt.want {
+				t.Errorf("keys = %q, want [%q]", limiter.keys, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterceptorRejectsExhaustedCaller(t *testing.T) {
+	interceptor := NewPeerInterceptor(NewMemoryLimiter(), Limit{Rate: 1, Burst: 2}, false)
+	ctx := peerContext("203.0.113.7:5000")
+
+	// Las credenciales inválidas consumen la misma cuota que el resto
+	for _, method := range []string{"/task.TaskService/GetTask", "/task.TaskService/CreateTask"} {
+		if err := callUnary(interceptor, ctx, method); err != nil {
+			t.Fatalf("%s within the burst: %v", method, err)
+		}
+	}
+
+	err := callUnary(interceptor, ctx, "/task.TaskService/DeleteTask")
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("error = %v, want ResourceExhausted", err)
+	}
+	var retry *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = info
+		}
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() <= 0 || retry.RetryDelay.AsDuration() > time.Second {
+		t.Errorf("retry info = %v, want a delay of at most 1s", retry)
+	}
+
+	if err := callUnary(interceptor, peerContext("198.51.100.1:5000"), "/task.TaskService/GetTask"); err != nil {
+		t.Errorf("another IP was limited: %v", err)
+	}
+}
+
+func TestInterceptorLimiterFailure(t *testing.T) {
+	failing := &recordingLimiter{err: errors.New("database unavailable")}
+
+	if err := callUnary(NewInterceptor(failing, Limits{}, true), context.Background(), "/task.TaskService/GetTask"); err != nil {
+		t.Errorf("fail open: error = %v, want nil", err)
+	}
+	if err := callUnary(NewInterceptor(failing, Limits{}, false), context.Background(), "/task.TaskService/GetTask"); status.Code(err) != codes.Unavailable {
+		t.Errorf("fail closed: error = %v, want Unavailable", err)
+	}
+
+	stream := NewPeerInterceptor(failing, Limit{}, false).StreamServerInterceptor()
+	called := false
+	err := stream(nil, &contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/task.TaskService/ExportTasks"},
+		func(any, grpc.ServerStream) error { called = true; return nil })
+	if status.Code(err) != codes.Unavailable || called {
+		t.Errorf("fail closed stream: error = %v, handler called = %t", err, called)
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit configures a token bucket: Rate tokens are added per second up to
+// Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limiter takes one token from the bucket identified by key. When no token
+// is available it returns false and how long to wait before retrying.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+// retryAfter returns the time needed to refill one token.
+func retryAfter(tokens float64, limit Limit) time.Duration {
+	if limit.Rate <= 0 {
+		return time.Minute
+	}
+	return time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+}
+
+// Limits resolves the bucket configuration of each method.
+type Limits struct {
+	Default Limit
+	Methods map[string]Limit
+}
+
+func (l Limits) ForMethod(fullMethod string) Limit {
+	if limit, ok := l.Methods[fullMethod]; ok {
+		return limit
+	}
+	if limit, ok := l.Methods[path.Base(fullMethod)]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// ParseLimits parses entries with the form "default=10:20,ListAllTasks=1:5",
+// where each value is "rate per second:burst".
+func ParseLimits(value string) (Limits, error) {
+	limits := Limits{
+		Default: Limit{Rate: 10, Burst: 20},
+		Methods: make(map[string]Limit),
+	}
+	if value == "" {
+		return limits, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, spec, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" {
+			return Limits{}, fmt.Errorf("invalid rate limit %q", entry)
+		}
+
+		limit, err := ParseLimit(spec)
+		if err != nil {
+			return Limits{}, fmt.Errorf("invalid rate limit for %s: %w", name, err)
+		}
+		if name == "default" {
+			limits.Default = limit
+		} else {
+			limits.Methods[name] = limit
+		}
+	}
+
+	return limits, nil
+}
+
+// ParseLimit parses a single "rate per second:burst" value.
+func ParseLimit(spec string) (Limit, error) {
+	rateStr, burstStr, found := strings.Cut(spec, ":")
+	if !found {
+		return Limit{}, fmt.Errorf("%q: expected rate:burst", spec)
+	}
+
+	rate, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || rate < 0 {
+		return Limit{}, fmt.Errorf("invalid rate %q", rateStr)
+	}
+	burst, err := strconv.Atoi(burstStr)
+	if err != nil || burst < 1 {
+		return Limit{}, fmt.Errorf("invalid burst %q", burstStr)
+	}
+
+	return Limit{Rate: rate, Burst: burst}, nil
+}
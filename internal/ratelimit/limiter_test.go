@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLimits(t *testing.T) {
+	defaults := Limit{Rate: 10, Burst: 20}
+	tests := []struct {
+		value string
+		want  Limits
+	}{
+		{"", Limits{Default: defaults, Methods: map[string]Limit{}}},
+		{"default=1:2", Limits{Default: Limit{Rate: 1, Burst: 2}, Methods: map[string]Limit{}}},
+		{
+			value: "ListAllTasks=0.5:5, /task.TaskService/GetTask=100:200",
+			want: Limits{Default: defaults, Methods: map[string]Limit{
+				"ListAllTasks":              {Rate: 0.5, Burst: 5},
+				"/task.TaskService/GetTask": {Rate: 100, Burst: 200},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLimits(tt.value)
+		if err != nil {
+			t.Errorf("ParseLimits(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLimits(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseLimitsErrors(t *testing.T) {
+	for _, value := range []string{
+		"default",
+		"=1:2",
+		"default=1",
+		"default=x:2",
+		"default=-1:2",
+		"default=1:0",
+		"default=1:2.5",
+		"default=1:2,",
+	} {
+		if _, err := ParseLimits(value); err == nil {
+			t.Errorf("ParseLimits(%q) accepted an invalid value", value)
+		}
+	}
+}
+
+func TestLimitsForMethod(t *testing.T) {
+	limits := Limits{
+		Default: Limit{Rate: 1, Burst: 1},
+		Methods: map[string]Limit{
+			"ListAllTasks":              {Rate: 2, Burst: 2},
+			"/task.TaskService/GetTask": {Rate: 3, Burst: 3},
+		},
+	}
+	tests := map[string]Limit{
+		"/task.TaskService/ListAllTasks": {Rate: 2, Burst: 2},
+		"/task.TaskService/GetTask":      {Rate: 3, Burst: 3},
+		"/other.Service/GetTask":         {Rate: 1, Burst: 1},
+		"/task.TaskService/CreateTask":   {Rate: 1, Burst: 1},
+	}
+
+	for method, want := range tests {
+		if got := limits.ForMethod(method); got != want {
+			t.Errorf("ForMethod(%q) = %+v, want %+v", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manual clock for the memory limiter.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter() (*MemoryLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewMemoryLimiter()
+	limiter.now = clock.now
+	limiter.lastSweep = clock.t
+	return limiter, clock
+}
+
+func TestMemoryLimiterTokenBucket(t *testing.T) {
+	limit := Limit{Rate: 2, Burst: 3}
+	limiter, clock := newTestLimiter()
+	ctx := context.Background()
+
+	allow := func() (bool, time.Duration) {
+		t.Helper()
+		allowed, wait, err := limiter.Allow(ctx, "alice", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return allowed, wait
+	}
+
+	// La ráfaga completa se concede de inmediato
+	for i := range limit.Burst {
+		if allowed, _ := allow(); !allowed {
+			t.Fatalf("request %d of the burst was rejected", i+1)
+		}
+	}
+	allowed, wait := allow()
+	if allowed {
+		t.Fatal("request after the burst was allowed")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("wait = %s, want 500ms", wait)
+	}
+
+	// A 2 tokens/s, en 250ms solo hay medio token
+	clock.advance(250 * time.Millisecond)
+	if allowed, wait := allow(); allowed || wait != 250*time.Millisecond {
+		t.Errorf("after 250ms: allowed = %t, wait = %s; want false, 250ms", allowed, wait)
+	}
+	clock.advance(250 * time.Millisecond)
+	if allowed, _ := allow(); !allowed {
+		t.Error("request after refilling one token was rejected")
+	}
+
+	// El bucket no pasa de la ráfaga por mucho que espere
+	clock.advance(time.Hour)
+	for range limit.Burst {
+		if allowed, _ := allow(); !allowed {
+			t.Fatal("request of a refilled burst was rejected")
+		}
+	}
+	if allowed, _ := allow(); allowed {
+		t.Error("bucket refilled beyond the burst")
+	}
+}
+
+func TestMemoryLimiterKeysAreIndependent(t *testing.T) {
+	limit := Limit{Rate: 0, Burst: 1}
+	limiter, _ := newTestLimiter()
+	ctx := context.Background()
+
+	for _, key := range []string{"alice", "bob"} {
+		if allowed, _, _ := limiter.Allow(ctx, key, limit); !allowed {
+			t.Errorf("first request of %s was rejected", key)
+		}
+	}
+	allowed, wait, _ := limiter.Allow(ctx, "alice", limit)
+	if allowed {
+		t.Error("second request of alice was allowed")
+	}
+	// Sin recarga se pide esperar el máximo
+	if wait != time.Minute {
+		t.Errorf("wait with rate 0 = %s, want 1m", wait)
+	}
+}
+
+func TestMemoryLimiterSweep(t *testing.T) {
+	limit := Limit{Rate: 1, Burst: 1}
+	limiter, clock := newTestLimiter()
+	ctx := context.Background()
+
+	limiter.Allow(ctx, "idle", limit)
+	clock.advance(idleBucketTTL / 2)
+	limiter.Allow(ctx, "active", limit)
+	clock.advance(idleBucketTTL/2 + time.Second)
+	limiter.Allow(ctx, "active", limit)
+
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Error("idle bucket was not swept")
+	}
+	if _, ok := limiter.buckets["active"]; !ok {
+		t.Error("active bucket was swept")
+	}
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresLimiter stores the buckets in a shared table so every replica
+// enforces the same global quota.
+type PostgresLimiter struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresLimiter(dbPool *pgxpool.Pool) *PostgresLimiter {
+	return &PostgresLimiter{
+		dbpool: dbPool,
+	}
+}
+
+func (l *PostgresLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	// El relleno y el consumo del token se calculan sobre la fila bloqueada
+	// por ON CONFLICT, así la operación es atómica entre réplicas.
+	const query = `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+			VALUES ($1, $3::float8 - 1, true, NOW())
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = LEAST($3::float8, b.tokens + EXTRACT(EPOCH FROM (NOW() - b.updated_at))::float8 * $2::float8)
+				- (LEAST($3::float8, b.tokens + EXTRACT(EPOCH FROM (NOW() - b.updated_at))::float8 * $2::float8) >= 1)::int,
+			allowed = LEAST($3::float8, b.tokens + EXTRACT(EPOCH FROM (NOW() - b.updated_at))::float8 * $2::float8) >= 1,
+			updated_at = NOW()
+		RETURNING tokens, allowed;
+	`
+
+	var (
+		tokens  float64
+		allowed bool
+	)
+	err := l.dbpool.QueryRow(ctx, query, key, limit.Rate, limit.Burst).Scan(&tokens, &allowed)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to update rate limit bucket: %w", err)
+	}
+
+	if !allowed {
+		return false, retryAfter(tokens, limit), nil
+	}
+	return true, 0, nil
+}
+
+// Sweep deletes the buckets idle for longer than idleBucketTTL every
+// interval until ctx is done, as the memory limiter does, so that the table
+// only holds the recent callers.
+func (l *PostgresLimiter) Sweep(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := l.deleteIdle(ctx, idleBucketTTL)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Warn("Failed to delete idle rate limit buckets", "error", err)
+			}
+			continue
+		}
+		if deleted > 0 {
+			slog.Debug("Deleted idle rate limit buckets", "count", deleted)
+		}
+	}
+}
+
+// deleteIdle deletes the buckets not used for ttl. They are full again by
+// then, as long as the limit refills in less than ttl.
+func (l *PostgresLimiter) deleteIdle(ctx context.Context, ttl time.Duration) (int64, error) {
+	const query = "DELETE FROM rate_limit_buckets WHERE updated_at < NOW() - $1::interval;"
+
+	result, err := l.dbpool.Exec(ctx, query, ttl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete idle rate limit buckets: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
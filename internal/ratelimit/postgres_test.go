@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newTestPostgresLimiter connects to the database in TEST_DATABASE_URL and
+// creates an empty rate_limit_buckets table, skipping the test without it.
+func newTestPostgresLimiter(t *testing.T) (*PostgresLimiter, *pgxpool.Pool) {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	schema, err := os.ReadFile("../../migrations/rate_limit_buckets.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx, string(schema)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx, "DELETE FROM rate_limit_buckets WHERE key LIKE 'test:%';"); err != nil {
+		t.Fatal(err)
+	}
+	return NewPostgresLimiter(pool), pool
+}
+
+func TestPostgresLimiterTokenBucket(t *testing.T) {
+	limiter, _ := newTestPostgresLimiter(t)
+	ctx := context.Background()
+	limit := Limit{Rate: 0.001, Burst: 3}
+
+	for i := range limit.Burst {
+		allowed, _, err := limiter.Allow(ctx, "test:alice", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !allowed {
+			t.Fatalf("request %d of the burst was rejected", i+1)
+		}
+	}
+
+	allowed, wait, err := limiter.Allow(ctx, "test:alice", limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allowed || wait <= 0 {
+		t.Errorf("request after the burst: allowed = %t, wait = %s", allowed, wait)
+	}
+
+	if allowed, _, err := limiter.Allow(ctx, "test:bob", limit); err != nil || !allowed {
+		t.Errorf("first request of another key: allowed = %t, error = %v", allowed, err)
+	}
+}
+
+func TestPostgresLimiterRefill(t *testing.T) {
+	limiter, pool := newTestPostgresLimiter(t)
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	for range limit.Burst + 1 {
+		if _, _, err := limiter.Allow(ctx, "test:alice", limit); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Un segundo y medio atrás equivale a 1,5 tokens recargados
+	if _, err := pool.Exec(ctx, "UPDATE rate_limit_buckets SET updated_at = updated_at - interval '1.5 seconds' WHERE key = 'test:alice';"); err != nil {
+		t.Fatal(err)
+	}
+	if allowed, _, err := limiter.Allow(ctx, "test:alice", limit); err != nil || !allowed {
+		t.Errorf("request after refilling: allowed = %t, error = %v", allowed, err)
+	}
+}
+
+func TestPostgresLimiterDeleteIdle(t *testing.T) {
+	limiter, pool := newTestPostgresLimiter(t)
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 1}
+
+	for _, key := range []string{"test:idle", "test:active"} {
+		if _, _, err := limiter.Allow(ctx, key, limit); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := pool.Exec(ctx, "UPDATE rate_limit_buckets SET updated_at = NOW() - interval '1 hour' WHERE key = 'test:idle';"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := limiter.deleteIdle(ctx, 10*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	rows, err := pool.Query(ctx, "SELECT key FROM rate_limit_buckets WHERE key LIKE 'test:%' ORDER BY key;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != "test:active" {
+		t.Errorf("keys after deleting idle buckets = %q, want [test:active]", keys)
+	}
+}
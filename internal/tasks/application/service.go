@@ -2,23 +2,124 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
 	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tracing"
 	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/Mayer-04/grpc-task-manager-go/internal/tasks/application")
+
 type TaskService struct {
 	taskRepo domain.TaskRepository
+	policy   *auth.Policy
 }
 
-func NewTaskService(taskRepo domain.TaskRepository) *TaskService {
+func NewTaskService(taskRepo domain.TaskRepository, policy *auth.Policy) *TaskService {
+	if policy == nil {
+		policy = auth.DefaultPolicy()
+	}
+
 	return &TaskService{
 		taskRepo: taskRepo,
+		policy:   policy,
+	}
+}
+
+// authorize checks the caller in ctx against the policy. Requests without a
+// principal (authentication disabled) are always allowed.
+func (s *TaskService) authorize(ctx context.Context, action auth.Action, ownerID string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if !s.policy.Allowed(principal, action, ownerID) {
+		return fmt.Errorf("%w: %s is not allowed to perform %s", domain.ErrPermissionDenied, principal.Subject, action)
+	}
+	return nil
+}
+
+// getAuthorizedTask loads a task and checks the caller may perform action on
+// it. Callers that may not even read the task get ErrTaskNotFound, as if it
+// did not exist, so task IDs cannot be probed.
+func (s *TaskService) getAuthorizedTask(ctx context.Context, taskID string, action auth.Action) (*domain.Task, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("task_id is required")
+	}
+
+	// Validar que sea un UUID válido
+	if _, err := uuid.FromString(taskID); err != nil {
+		return nil, fmt.Errorf("invalid task_id format: %w", err)
+	}
+
+	task, err := s.taskRepo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeTask(ctx, task, action); err != nil {
+		if !errors.Is(err, domain.ErrPermissionDenied) {
+			return nil, err
+		}
+		// Quien ni siquiera puede leerla no debe saber si existe
+		readErr := err
+		if action != auth.ActionRead {
+			readErr = s.authorizeTask(ctx, task, auth.ActionRead)
+		}
+		switch {
+		case readErr == nil:
+			return nil, err
+		case errors.Is(readErr, domain.ErrPermissionDenied):
+			return nil, fmt.Errorf("%w: %s", domain.ErrTaskNotFound, taskID)
+		default:
+			return nil, readErr
+		}
+	}
+
+	return task, nil
+}
+
+// requiredPermissions maps the actions a collaborator can perform to the
+// grant they need. Actions missing here are reserved to the policy.
+var requiredPermissions = map[auth.Action]domain.Permission{
+	auth.ActionRead:     domain.PermissionRead,
+	auth.ActionUpdate:   domain.PermissionWrite,
+	auth.ActionComplete: domain.PermissionWrite,
+}
+
+// authorizeTask checks the policy first and then falls back to the
+// collaborator grants stored for the task.
+func (s *TaskService) authorizeTask(ctx context.Context, task *domain.Task, action auth.Action) error {
+	policyErr := s.authorize(ctx, action, task.UserID)
+	if policyErr == nil {
+		return nil
 	}
+
+	required, ok := requiredPermissions[action]
+	if !ok {
+		return policyErr
+	}
+
+	principal, _ := auth.FromContext(ctx)
+	granted, err := s.taskRepo.GetCollaboratorPermission(ctx, task.ID.String(), principal.Subject)
+	if err != nil {
+		return err
+	}
+	if !granted.Allows(required) {
+		return policyErr
+	}
+
+	return nil
 }
 
-func (s *TaskService) CreateTask(ctx context.Context, userID, title, description string, completed bool) (*domain.Task, error) {
+func (s *TaskService) CreateTask(ctx context.Context, userID, title, description string, completed bool) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.CreateTask")
+	defer tracing.End(span, &err)
+
 	if userID == "" {
 		return nil, fmt.Errorf("user_id is required")
 	}
@@ -26,6 +127,10 @@ func (s *TaskService) CreateTask(ctx context.Context, userID, title, description
 		return nil, fmt.Errorf("title is required")
 	}
 
+	if err := s.authorize(ctx, auth.ActionCreate, userID); err != nil {
+		return nil, err
+	}
+
 	task := &domain.Task{
 		UserID:      userID,
 		Title:       title,
@@ -36,31 +141,19 @@ func (s *TaskService) CreateTask(ctx context.Context, userID, title, description
 	return s.taskRepo.CreateTask(ctx, task)
 }
 
-func (s *TaskService) GetTask(ctx context.Context, taskID string) (*domain.Task, error) {
-	if taskID == "" {
-		return nil, fmt.Errorf("task_id is required")
-	}
+func (s *TaskService) GetTask(ctx context.Context, taskID string) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetTask")
+	defer tracing.End(span, &err)
 
-	// Validar que sea un UUID válido
-	if _, err := uuid.FromString(taskID); err != nil {
-		return nil, fmt.Errorf("invalid task_id format: %w", err)
-	}
-
-	return s.taskRepo.GetTask(ctx, taskID)
+	return s.getAuthorizedTask(ctx, taskID, auth.ActionRead)
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, taskID string, title, description *string, completed *bool) (*domain.Task, error) {
-	if taskID == "" {
-		return nil, fmt.Errorf("task_id is required")
-	}
-
-	// Validar que sea un UUID válido
-	if _, err := uuid.FromString(taskID); err != nil {
-		return nil, fmt.Errorf("invalid task_id format: %w", err)
-	}
+func (s *TaskService) UpdateTask(ctx context.Context, taskID string, title, description *string, completed *bool) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.UpdateTask")
+	defer tracing.End(span, &err)
 
 	// Obtener la tarea existente
-	existingTask, err := s.taskRepo.GetTask(ctx, taskID)
+	existingTask, err := s.getAuthorizedTask(ctx, taskID, auth.ActionUpdate)
 	if err != nil {
 		return nil, err
 	}
@@ -79,40 +172,108 @@ func (s *TaskService) UpdateTask(ctx context.Context, taskID string, title, desc
 	return s.taskRepo.UpdateTask(ctx, existingTask)
 }
 
-func (s *TaskService) DeleteTask(ctx context.Context, taskID string) error {
-	if taskID == "" {
-		return fmt.Errorf("task_id is required")
-	}
+func (s *TaskService) DeleteTask(ctx context.Context, taskID string) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.DeleteTask")
+	defer tracing.End(span, &err)
 
-	// Validar que sea un UUID válido
-	if _, err := uuid.FromString(taskID); err != nil {
-		return fmt.Errorf("invalid task_id format: %w", err)
+	if _, err := s.getAuthorizedTask(ctx, taskID, auth.ActionDelete); err != nil {
+		return err
 	}
 
 	return s.taskRepo.DeleteTask(ctx, taskID)
 }
 
-func (s *TaskService) MarkTaskComplete(ctx context.Context, taskID string) (*domain.Task, error) {
-	if taskID == "" {
-		return nil, fmt.Errorf("task_id is required")
-	}
+func (s *TaskService) MarkTaskComplete(ctx context.Context, taskID string) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.MarkTaskComplete")
+	defer tracing.End(span, &err)
 
-	// Validar que sea un UUID válido
-	if _, err := uuid.FromString(taskID); err != nil {
-		return nil, fmt.Errorf("invalid task_id format: %w", err)
+	if _, err := s.getAuthorizedTask(ctx, taskID, auth.ActionComplete); err != nil {
+		return nil, err
 	}
 
 	return s.taskRepo.MarkTaskComplete(ctx, taskID)
 }
 
-func (s *TaskService) ListTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error) {
+func (s *TaskService) ListTasksByUser(ctx context.Context, userID string, includeShared bool, page domain.Page) (_ []*domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ListTasksByUser")
+	defer tracing.End(span, &err)
+
 	if userID == "" {
 		return nil, fmt.Errorf("user_id is required")
 	}
 
-	return s.taskRepo.ListTasksByUser(ctx, userID)
+	if err := s.authorize(ctx, auth.ActionList, userID); err != nil {
+		return nil, err
+	}
+
+	return s.taskRepo.ListTasksByUser(ctx, userID, includeShared, page)
 }
 
-func (s *TaskService) ListAllTasks(ctx context.Context) ([]*domain.Task, error) {
-	return s.taskRepo.ListAllTasks(ctx)
+func (s *TaskService) ListAllTasks(ctx context.Context, page domain.Page) (_ []*domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ListAllTasks")
+	defer tracing.End(span, &err)
+
+	if err := s.authorize(ctx, auth.ActionListAll, ""); err != nil {
+		return nil, err
+	}
+
+	return s.taskRepo.ListAllTasks(ctx, page)
+}
+
+func (s *TaskService) ShareTask(ctx context.Context, taskID, userID string, permission domain.Permission) (_ *domain.Collaborator, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ShareTask")
+	defer tracing.End(span, &err)
+
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if permission != domain.PermissionRead && permission != domain.PermissionWrite {
+		return nil, fmt.Errorf("invalid permission %q", permission)
+	}
+
+	task, err := s.getAuthorizedTask(ctx, taskID, auth.ActionShare)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.UserID == userID {
+		return nil, fmt.Errorf("cannot share a task with its owner")
+	}
+
+	return s.taskRepo.ShareTask(ctx, &domain.Collaborator{
+		TaskID:     task.ID,
+		UserID:     userID,
+		Permission: permission,
+	})
+}
+
+func (s *TaskService) UnshareTask(ctx context.Context, taskID, userID string) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.UnshareTask")
+	defer tracing.End(span, &err)
+
+	if userID == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	// Un colaborador siempre puede retirarse a sí mismo
+	if principal, ok := auth.FromContext(ctx); ok && principal.Subject == userID {
+		if _, err := s.getAuthorizedTask(ctx, taskID, auth.ActionRead); err != nil {
+			return err
+		}
+	} else if _, err := s.getAuthorizedTask(ctx, taskID, auth.ActionShare); err != nil {
+		return err
+	}
+
+	return s.taskRepo.UnshareTask(ctx, taskID, userID)
+}
+
+func (s *TaskService) ListCollaborators(ctx context.Context, taskID string) (_ []*domain.Collaborator, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ListCollaborators")
+	defer tracing.End(span, &err)
+
+	if _, err := s.getAuthorizedTask(ctx, taskID, auth.ActionRead); err != nil {
+		return nil, err
+	}
+
+	return s.taskRepo.ListCollaborators(ctx, taskID)
 }
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tracing"
+)
+
+// BulkOptions controls a bulk operation on the tasks selected by a filter.
+type BulkOptions struct {
+	// DryRun reports the tasks the operation would change, without writing.
+	DryRun bool
+	// Expected are the IDs of the tasks shown to the user in a preview, or
+	// nil to skip the check. If the filter selects any other set of tasks,
+	// nothing is changed.
+	Expected []string
+}
+
+// CompleteTasksMatching marks as completed the open tasks selected by
+// filter, calling progress for each of them. All of them are completed in a
+// single transaction, committed after the last call to progress.
+func (s *TaskService) CompleteTasksMatching(ctx context.Context, filter domain.TaskFilter, opts BulkOptions, progress domain.BulkProgress) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.CompleteTasksMatching")
+	defer tracing.End(span, &err)
+
+	if err := s.authorizeFilter(ctx, filter, auth.ActionComplete); err != nil {
+		return 0, err
+	}
+
+	if opts.DryRun {
+		completed := false
+		filter.Completed = &completed
+		return s.previewMatching(ctx, filter, progress)
+	}
+	return s.taskRepo.CompleteTasksMatching(ctx, filter, opts.Expected, progress)
+}
+
+// DeleteTasksMatching deletes the tasks selected by filter, calling
+// progress for each of them, in a single transaction.
+func (s *TaskService) DeleteTasksMatching(ctx context.Context, filter domain.TaskFilter, opts BulkOptions, progress domain.BulkProgress) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.DeleteTasksMatching")
+	defer tracing.End(span, &err)
+
+	if err := s.authorizeFilter(ctx, filter, auth.ActionDelete); err != nil {
+		return 0, err
+	}
+
+	if opts.DryRun {
+		return s.previewMatching(ctx, filter, progress)
+	}
+	return s.taskRepo.DeleteTasksMatching(ctx, filter, opts.Expected, progress)
+}
+
+// authorizeFilter checks the caller may perform action on the tasks of the
+// filter's user. Shared tasks are never selected, so the owner's policy is
+// enough for all of them.
+func (s *TaskService) authorizeFilter(ctx context.Context, filter domain.TaskFilter, action auth.Action) error {
+	if filter.UserID == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if filter.CreatedBefore != nil && filter.CreatedAfter != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return fmt.Errorf("created_after must be before created_before")
+	}
+	return s.authorize(ctx, action, filter.UserID)
+}
+
+func (s *TaskService) previewMatching(ctx context.Context, filter domain.TaskFilter, progress domain.BulkProgress) (int, error) {
+	tasks, err := s.taskRepo.ListTasksMatching(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	for _, task := range tasks {
+		if err := progress(task, len(tasks)); err != nil {
+			return 0, err
+		}
+	}
+	return len(tasks), nil
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tracing"
+	"github.com/gofrs/uuid"
+)
+
+// ImportOptions controls which fields of an exported task are kept.
+type ImportOptions struct {
+	PreserveIDs        bool
+	PreserveTimestamps bool
+	// DryRun validates the task and checks its ID without writing.
+	DryRun bool
+}
+
+// exportBatchSize is the number of tasks read per query while exporting.
+const exportBatchSize = 500
+
+// ExportTasks calls send with every task owned by userID, or with the tasks
+// of every user when allUsers is set, in creation order. Shared tasks are
+// left out since they belong to someone else. The tasks are read in
+// batches, so memory does not grow with the number of tasks.
+func (s *TaskService) ExportTasks(ctx context.Context, userID string, allUsers bool, send func(*domain.Task) error) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ExportTasks")
+	defer tracing.End(span, &err)
+
+	list := func(page domain.Page) ([]*domain.Task, error) {
+		return s.taskRepo.ListTasksByUser(ctx, userID, false, page)
+	}
+	if allUsers {
+		if err := s.authorize(ctx, auth.ActionListAll, ""); err != nil {
+			return err
+		}
+		list = func(page domain.Page) ([]*domain.Task, error) {
+			return s.taskRepo.ListAllTasks(ctx, page)
+		}
+	} else {
+		if userID == "" {
+			return fmt.Errorf("user_id is required")
+		}
+		if err := s.authorize(ctx, auth.ActionList, userID); err != nil {
+			return err
+		}
+	}
+
+	page := domain.Page{Limit: exportBatchSize}
+	for {
+		tasks, err := list(page)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			if err := send(task); err != nil {
+				return err
+			}
+		}
+		if len(tasks) < page.Limit {
+			return nil
+		}
+
+		// La siguiente consulta continúa tras la última tarea enviada
+		last := tasks[len(tasks)-1]
+		page.After = &domain.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// ImportTask creates a task from an exported one. It returns
+// domain.ErrTaskExists when the preserved ID is already taken, so callers
+// can count the task as skipped rather than failed.
+func (s *TaskService) ImportTask(ctx context.Context, task *domain.Task, opts ImportOptions) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ImportTask")
+	defer tracing.End(span, &err)
+
+	if task.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if task.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if err := s.authorize(ctx, auth.ActionCreate, task.UserID); err != nil {
+		return nil, err
+	}
+
+	preserveID := opts.PreserveIDs && task.ID != uuid.Nil
+	if opts.DryRun {
+		if preserveID {
+			exists, err := s.taskRepo.TaskExists(ctx, task.ID.String())
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: %s", domain.ErrTaskExists, task.ID)
+			}
+		}
+		return task, nil
+	}
+
+	return s.taskRepo.ImportTask(ctx, task, preserveID, opts.PreserveTimestamps)
+}
@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/gofrs/uuid"
+)
+
+// memoryRepo keeps tasks and grants in memory. Methods the tests do not
+// use panic through the embedded nil interface.
+type memoryRepo struct {
+	domain.TaskRepository
+	tasks  map[string]*domain.Task
+	grants map[string]domain.Permission // "taskID|userID"
+}
+
+func (r *memoryRepo) GetTask(_ context.Context, id string) (*domain.Task, error) {
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTaskNotFound, id)
+	}
+	return task, nil
+}
+
+func (r *memoryRepo) DeleteTask(_ context.Context, id string) error {
+	delete(r.tasks, id)
+	return nil
+}
+
+func (r *memoryRepo) GetCollaboratorPermission(_ context.Context, taskID, userID string) (domain.Permission, error) {
+	return r.grants[taskID+"|"+userID], nil
+}
+
+func TestGetAuthorizedTaskHidesUnreadableTasks(t *testing.T) {
+	taskID := uuid.Must(uuid.NewV4())
+	missingID := uuid.Must(uuid.NewV4()).String()
+	newService := func() *TaskService {
+		repo := &memoryRepo{
+			tasks:  map[string]*domain.Task{taskID.String(): {ID: taskID, UserID: "alice", Title: "secret plan"}},
+			grants: map[string]domain.Permission{taskID.String() + "|carol": domain.PermissionRead},
+		}
+		return NewTaskService(repo, auth.DefaultPolicy())
+	}
+	as := func(subject string, roles ...string) context.Context {
+		return auth.NewContext(context.Background(), &auth.Principal{Subject: subject, Roles: roles})
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		delete  bool
+		taskID  string
+		wantErr error
+	}{
+		{"owner reads", as("alice"), false, taskID.String(), nil},
+		{"owner deletes", as("alice"), true, taskID.String(), nil},
+		{"stranger reads", as("bob"), false, taskID.String(), domain.ErrTaskNotFound},
+		{"stranger deletes", as("bob"), true, taskID.String(), domain.ErrTaskNotFound},
+		{"missing task", as("bob"), false, missingID, domain.ErrTaskNotFound},
+		// Quien puede leer la tarea sí sabe que existe
+		{"viewer deletes", as("vera", auth.RoleViewer), true, taskID.String(), domain.ErrPermissionDenied},
+		{"reader collaborator reads", as("carol"), false, taskID.String(), nil},
+		{"reader collaborator deletes", as("carol"), true, taskID.String(), domain.ErrPermissionDenied},
+		{"admin deletes", as("root", auth.RoleAdmin), true, taskID.String(), nil},
+		{"authentication disabled", context.Background(), true, taskID.String(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newService()
+			var err error
+			if tt.delete {
+				err = service.DeleteTask(tt.ctx, tt.taskID)
+			} else {
+				_, err = service.GetTask(tt.ctx, tt.taskID)
+			}
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			// Nunca se revela que la tarea existe pero es ajena
+			if tt.wantErr == domain.ErrTaskNotFound && errors.Is(err, domain.ErrPermissionDenied) {
+				t.Errorf("error = %v also reports permission denied", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Permission is the access level granted to a collaborator.
+type Permission string
+
+const (
+	PermissionRead  Permission = "read"
+	PermissionWrite Permission = "write"
+)
+
+// Allows reports whether p grants at least the required permission.
+func (p Permission) Allows(required Permission) bool {
+	switch p {
+	case PermissionWrite:
+		return required == PermissionRead || required == PermissionWrite
+	case PermissionRead:
+		return required == PermissionRead
+	}
+	return false
+}
+
+type Collaborator struct {
+	TaskID     uuid.UUID
+	UserID     string
+	Permission Permission
+	CreatedAt  time.Time
+}
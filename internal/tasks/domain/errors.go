@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrTaskExists       = errors.New("task already exists")
+	ErrTaskNotFound     = errors.New("task not found")
+	// ErrCollaboratorNotFound means the user is not a collaborator of the task.
+	ErrCollaboratorNotFound = errors.New("collaborator not found")
+	// ErrFilterChanged means a filter no longer selects the tasks that were
+	// previewed, so a bulk operation was not applied.
+	ErrFilterChanged = errors.New("filter no longer matches the previewed tasks")
+)
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// TaskFilter selects the tasks of a bulk operation. Empty fields match
+// every task; only the tasks owned by UserID are selected.
+type TaskFilter struct {
+	UserID string
+	// Tags are words of the title written as +tag, @tag or #tag. A task
+	// must have all of them.
+	Tags          []string
+	Completed     *bool
+	TitleContains string
+	CreatedBefore *time.Time
+	CreatedAfter  *time.Time
+}
+
+// BulkProgress is called for every task changed by a bulk operation, before
+// the transaction is committed. Returning an error rolls it back.
+type BulkProgress func(task *Task, total int) error
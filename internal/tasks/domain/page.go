@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// PageCursor is the position of a task in the order of paginated lists:
+// creation time, then ID.
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Page selects the tasks that follow a cursor.
+type Page struct {
+	After *PageCursor // nil: desde la primera tarea
+	Limit int         // 0: todas las tareas restantes
+}
@@ -5,9 +5,31 @@ import "context"
 type TaskRepository interface {
 	CreateTask(ctx context.Context, task *Task) (*Task, error)
 	GetTask(ctx context.Context, id string) (*Task, error)
-	ListAllTasks(ctx context.Context) ([]*Task, error)
+	// ListAllTasks and ListTasksByUser return a page of tasks ordered by
+	// creation time and ID. With includeShared, ListTasksByUser also returns
+	// the tasks shared with the user.
+	ListAllTasks(ctx context.Context, page Page) ([]*Task, error)
 	UpdateTask(ctx context.Context, task *Task) (*Task, error)
 	DeleteTask(ctx context.Context, id string) error
-	ListTasksByUser(ctx context.Context, userID string) ([]*Task, error)
+	ListTasksByUser(ctx context.Context, userID string, includeShared bool, page Page) ([]*Task, error)
 	MarkTaskComplete(ctx context.Context, id string) (*Task, error)
+	CountTasksByStatus(ctx context.Context) (open, completed int64, err error)
+	// ImportTask inserts a task keeping its ID and/or timestamps when
+	// requested, and returns ErrTaskExists when the preserved ID is taken.
+	ImportTask(ctx context.Context, task *Task, preserveID, preserveTimestamps bool) (*Task, error)
+	TaskExists(ctx context.Context, id string) (bool, error)
+	// ListTasksMatching returns the tasks selected by filter, oldest first.
+	ListTasksMatching(ctx context.Context, filter TaskFilter) ([]*Task, error)
+	// CompleteTasksMatching and DeleteTasksMatching change the tasks selected
+	// by filter in a single transaction. When expected is not nil and the
+	// filter selects other tasks than the expected IDs, nothing is changed
+	// and ErrFilterChanged is returned.
+	CompleteTasksMatching(ctx context.Context, filter TaskFilter, expected []string, progress BulkProgress) (int, error)
+	DeleteTasksMatching(ctx context.Context, filter TaskFilter, expected []string, progress BulkProgress) (int, error)
+
+	ShareTask(ctx context.Context, collaborator *Collaborator) (*Collaborator, error)
+	UnshareTask(ctx context.Context, taskID, userID string) error
+	ListCollaborators(ctx context.Context, taskID string) ([]*Collaborator, error)
+	// GetCollaboratorPermission returns an empty Permission when the task is not shared with the user.
+	GetCollaboratorPermission(ctx context.Context, taskID, userID string) (Permission, error)
 }
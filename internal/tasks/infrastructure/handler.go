@@ -2,7 +2,9 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 
+	"github.com/Mayer-04/grpc-task-manager-go/internal/auth"
 	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/application"
 	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
 	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
@@ -33,9 +35,11 @@ func (h *TaskHandler) CreateTask(ctx context.Context, req *taskpb.CreateTaskRequ
 		description = *req.Description
 	}
 
-	task, err := h.taskService.CreateTask(ctx, req.UserId, req.Title, description, completed)
+	userID := resolveUserID(ctx, req.UserId)
+
+	task, err := h.taskService.CreateTask(ctx, userID, req.Title, description, completed)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create task: %v", err)
+		return nil, toStatusError(err, codes.Internal, "failed to create task")
 	}
 
 	return &taskpb.CreateTaskResponse{
@@ -46,7 +50,7 @@ func (h *TaskHandler) CreateTask(ctx context.Context, req *taskpb.CreateTaskRequ
 func (h *TaskHandler) GetTask(ctx context.Context, req *taskpb.GetTaskRequest) (*taskpb.GetTaskResponse, error) {
 	task, err := h.taskService.GetTask(ctx, req.Id)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "task not found: %v", err)
+		return nil, toStatusError(err, codes.NotFound, "task not found")
 	}
 
 	return &taskpb.GetTaskResponse{
@@ -57,7 +61,7 @@ func (h *TaskHandler) GetTask(ctx context.Context, req *taskpb.GetTaskRequest) (
 func (h *TaskHandler) UpdateTask(ctx context.Context, req *taskpb.UpdateTaskRequest) (*taskpb.UpdateTaskResponse, error) {
 	task, err := h.taskService.UpdateTask(ctx, req.Id, req.Title, req.Description, req.Completed)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update task: %v", err)
+		return nil, toStatusError(err, codes.Internal, "failed to update task")
 	}
 
 	return &taskpb.UpdateTaskResponse{
@@ -71,7 +75,7 @@ func (h *TaskHandler) DeleteTask(ctx context.Context, req *taskpb.DeleteTaskRequ
 		return &taskpb.DeleteTaskResponse{
 			Success: false,
 			Message: err.Error(),
-		}, status.Errorf(codes.Internal, "failed to delete task: %v", err)
+		}, toStatusError(err, codes.Internal, "failed to delete task")
 	}
 
 	return &taskpb.DeleteTaskResponse{
@@ -83,7 +87,7 @@ func (h *TaskHandler) DeleteTask(ctx context.Context, req *taskpb.DeleteTaskRequ
 func (h *TaskHandler) MarkTaskComplete(ctx context.Context, req *taskpb.MarkTaskCompleteRequest) (*taskpb.MarkTaskCompleteResponse, error) {
 	task, err := h.taskService.MarkTaskComplete(ctx, req.Id)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to mark task complete: %v", err)
+		return nil, toStatusError(err, codes.Internal, "failed to mark task complete")
 	}
 
 	return &taskpb.MarkTaskCompleteResponse{
@@ -92,10 +96,16 @@ func (h *TaskHandler) MarkTaskComplete(ctx context.Context, req *taskpb.MarkTask
 }
 
 func (h *TaskHandler) ListTasksByUser(ctx context.Context, req *taskpb.ListTasksByUserRequest) (*taskpb.ListTasksResponse, error) {
-	tasks, err := h.taskService.ListTasksByUser(ctx, req.UserId)
+	page, err := pageRequest(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tasks, err := h.taskService.ListTasksByUser(ctx, resolveUserID(ctx, req.UserId), req.IncludeShared, page)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list tasks: %v", err)
+		return nil, toStatusError(err, codes.Internal, "failed to list tasks")
 	}
+	tasks, nextPageToken := pageResult(tasks, page)
 
 	var protoTasks []*taskpb.Task
 	for _, task := range tasks {
@@ -103,15 +113,22 @@ func (h *TaskHandler) ListTasksByUser(ctx context.Context, req *taskpb.ListTasks
 	}
 
 	return &taskpb.ListTasksResponse{
-		Tasks: protoTasks,
+		Tasks:         protoTasks,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
 func (h *TaskHandler) ListAllTasks(ctx context.Context, req *taskpb.ListAllTasksRequest) (*taskpb.ListTasksResponse, error) {
-	tasks, err := h.taskService.ListAllTasks(ctx)
+	page, err := pageRequest(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tasks, err := h.taskService.ListAllTasks(ctx, page)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list all tasks: %v", err)
+		return nil, toStatusError(err, codes.Internal, "failed to list all tasks")
 	}
+	tasks, nextPageToken := pageResult(tasks, page)
 
 	var protoTasks []*taskpb.Task
 	for _, task := range tasks {
@@ -119,13 +136,89 @@ func (h *TaskHandler) ListAllTasks(ctx context.Context, req *taskpb.ListAllTasks
 	}
 
 	return &taskpb.ListTasksResponse{
-		Tasks: protoTasks,
+		Tasks:         protoTasks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (h *TaskHandler) ShareTask(ctx context.Context, req *taskpb.ShareTaskRequest) (*taskpb.ShareTaskResponse, error) {
+	permission, ok := protoPermissionToDomain[req.Permission]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid permission: %v", req.Permission)
+	}
+
+	collaborator, err := h.taskService.ShareTask(ctx, req.TaskId, req.UserId, permission)
+	if err != nil {
+		return nil, toStatusError(err, codes.Internal, "failed to share task")
+	}
+
+	return &taskpb.ShareTaskResponse{
+		Collaborator: h.domainCollaboratorToProto(collaborator),
+	}, nil
+}
+
+func (h *TaskHandler) UnshareTask(ctx context.Context, req *taskpb.UnshareTaskRequest) (*taskpb.UnshareTaskResponse, error) {
+	err := h.taskService.UnshareTask(ctx, req.TaskId, req.UserId)
+	if err != nil {
+		return &taskpb.UnshareTaskResponse{
+			Success: false,
+			Message: err.Error(),
+		}, toStatusError(err, codes.Internal, "failed to unshare task")
+	}
+
+	return &taskpb.UnshareTaskResponse{
+		Success: true,
+		Message: "Task unshared successfully",
 	}, nil
 }
 
+func (h *TaskHandler) ListCollaborators(ctx context.Context, req *taskpb.ListCollaboratorsRequest) (*taskpb.ListCollaboratorsResponse, error) {
+	collaborators, err := h.taskService.ListCollaborators(ctx, req.TaskId)
+	if err != nil {
+		return nil, toStatusError(err, codes.Internal, "failed to list collaborators")
+	}
+
+	var protoCollaborators []*taskpb.Collaborator
+	for _, collaborator := range collaborators {
+		protoCollaborators = append(protoCollaborators, h.domainCollaboratorToProto(collaborator))
+	}
+
+	return &taskpb.ListCollaboratorsResponse{
+		Collaborators: protoCollaborators,
+	}, nil
+}
+
+// resolveUserID defaults the user ID to the authenticated caller. Requests for
+// another user are passed through and authorized by the service.
+func resolveUserID(ctx context.Context, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+// toStatusError converts a service error into a gRPC status, using code
+// unless the error maps to a more specific one.
+func toStatusError(err error, code codes.Code, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, domain.ErrTaskNotFound), errors.Is(err, domain.ErrCollaboratorNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrFilterChanged):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
+
 // domainTaskToProto converts a domain.Task to a taskpb.Task.
 func (h *TaskHandler) domainTaskToProto(task *domain.Task) *taskpb.Task {
-	return &taskpb.Task{
+	protoTask := &taskpb.Task{
 		Id:        task.ID.String(),
 		UserId:    task.UserID,
 		Title:     task.Title,
@@ -133,4 +226,30 @@ func (h *TaskHandler) domainTaskToProto(task *domain.Task) *taskpb.Task {
 		CreatedAt: timestamppb.New(task.CreatedAt),
 		UpdatedAt: timestamppb.New(task.UpdatedAt),
 	}
+	if task.Description != "" {
+		protoTask.Description = &task.Description
+	}
+	return protoTask
+}
+
+var protoPermissionToDomain = map[taskpb.Permission]domain.Permission{
+	taskpb.Permission_PERMISSION_READ:  domain.PermissionRead,
+	taskpb.Permission_PERMISSION_WRITE: domain.PermissionWrite,
+}
+
+// domainCollaboratorToProto converts a domain.Collaborator to a taskpb.Collaborator.
+func (h *TaskHandler) domainCollaboratorToProto(collaborator *domain.Collaborator) *taskpb.Collaborator {
+	permission := taskpb.Permission_PERMISSION_UNSPECIFIED
+	for protoPermission, domainPermission := range protoPermissionToDomain {
+		if domainPermission == collaborator.Permission {
+			permission = protoPermission
+		}
+	}
+
+	return &taskpb.Collaborator{
+		TaskId:     collaborator.TaskID.String(),
+		UserId:     collaborator.UserID,
+		Permission: permission,
+		CreatedAt:  timestamppb.New(collaborator.CreatedAt),
+	}
 }
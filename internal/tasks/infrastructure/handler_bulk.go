@@ -0,0 +1,116 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/application"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *TaskHandler) CompleteTasksMatching(req *taskpb.CompleteTasksMatchingRequest, stream grpc.ServerStreamingServer[taskpb.TasksMatchingProgress]) error {
+	ctx := stream.Context()
+
+	filter, err := protoFilterToDomain(ctx, req.Filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	opts, err := bulkOptions(req.DryRun, req.ExpectedIds)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := h.taskService.CompleteTasksMatching(ctx, filter, opts, h.sendProgress(stream)); err != nil {
+		return toStatusError(err, codes.Internal, "failed to complete tasks")
+	}
+	return nil
+}
+
+func (h *TaskHandler) DeleteTasksMatching(req *taskpb.DeleteTasksMatchingRequest, stream grpc.ServerStreamingServer[taskpb.TasksMatchingProgress]) error {
+	ctx := stream.Context()
+
+	filter, err := protoFilterToDomain(ctx, req.Filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	opts, err := bulkOptions(req.DryRun, req.ExpectedIds)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := h.taskService.DeleteTasksMatching(ctx, filter, opts, h.sendProgress(stream)); err != nil {
+		return toStatusError(err, codes.Internal, "failed to delete tasks")
+	}
+	return nil
+}
+
+// bulkOptions validates the expected IDs, which are compared in their
+// canonical form with the IDs of the selected tasks.
+func bulkOptions(dryRun bool, expectedIDs []string) (application.BulkOptions, error) {
+	opts := application.BulkOptions{DryRun: dryRun}
+	if len(expectedIDs) == 0 {
+		return opts, nil
+	}
+
+	opts.Expected = make([]string, 0, len(expectedIDs))
+	for _, id := range expectedIDs {
+		parsed, err := uuid.FromString(id)
+		if err != nil {
+			return opts, fmt.Errorf("invalid expected id %q: %w", id, err)
+		}
+		opts.Expected = append(opts.Expected, parsed.String())
+	}
+	return opts, nil
+}
+
+// sendProgress streams every changed task. A failed Send, such as a client
+// that went away, rolls the whole operation back.
+func (h *TaskHandler) sendProgress(stream grpc.ServerStreamingServer[taskpb.TasksMatchingProgress]) domain.BulkProgress {
+	var processed int32
+	return func(task *domain.Task, total int) error {
+		processed++
+		return stream.Send(&taskpb.TasksMatchingProgress{
+			Task:      h.domainTaskToProto(task),
+			Processed: processed,
+			Total:     int32(total),
+		})
+	}
+}
+
+// protoFilterToDomain converts a taskpb.TaskFilter, defaulting the user to
+// the authenticated one.
+func protoFilterToDomain(ctx context.Context, filter *taskpb.TaskFilter) (domain.TaskFilter, error) {
+	result := domain.TaskFilter{
+		UserID:        resolveUserID(ctx, filter.GetUserId()),
+		Completed:     filter.Completed,
+		TitleContains: filter.GetTitleContains(),
+	}
+
+	for _, tag := range filter.GetTags() {
+		if tag == "" {
+			return result, fmt.Errorf("empty tag")
+		}
+		result.Tags = append(result.Tags, tag)
+	}
+	if ts := filter.GetCreatedBefore(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			return result, fmt.Errorf("invalid created_before: %w", err)
+		}
+		before := ts.AsTime()
+		result.CreatedBefore = &before
+	}
+	if ts := filter.GetCreatedAfter(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			return result, fmt.Errorf("invalid created_after: %w", err)
+		}
+		after := ts.AsTime()
+		result.CreatedAfter = &after
+	}
+
+	return result, nil
+}
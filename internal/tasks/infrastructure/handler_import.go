@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/application"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxImportErrors bounds the per-task errors returned by ImportTasks.
+const maxImportErrors = 100
+
+func (h *TaskHandler) ExportTasks(req *taskpb.ExportTasksRequest, stream grpc.ServerStreamingServer[taskpb.ExportTasksResponse]) error {
+	ctx := stream.Context()
+
+	userID := ""
+	if !req.AllUsers {
+		userID = resolveUserID(ctx, req.UserId)
+	}
+	err := h.taskService.ExportTasks(ctx, userID, req.AllUsers, func(task *domain.Task) error {
+		return stream.Send(&taskpb.ExportTasksResponse{Task: h.domainTaskToProto(task)})
+	})
+	if err != nil {
+		return toStatusError(err, codes.Internal, "failed to export tasks")
+	}
+	return nil
+}
+
+// ImportTasks creates the streamed tasks one by one. A failing task does
+// not stop the import; it is counted and reported in the summary.
+func (h *TaskHandler) ImportTasks(stream grpc.ClientStreamingServer[taskpb.ImportTasksRequest, taskpb.ImportTasksResponse]) error {
+	ctx := stream.Context()
+
+	var (
+		opts        application.ImportOptions
+		defaultUser string
+		index       int32
+	)
+	summary := &taskpb.ImportTasksResponse{}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			summary.DryRun = opts.DryRun
+			return stream.SendAndClose(summary)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch payload := req.Payload.(type) {
+		case *taskpb.ImportTasksRequest_Options:
+			if index > 0 {
+				return status.Error(codes.InvalidArgument, "import options must be sent before the first task")
+			}
+			opts = application.ImportOptions{
+				PreserveIDs:        payload.Options.PreserveIds,
+				PreserveTimestamps: payload.Options.PreserveTimestamps,
+				DryRun:             payload.Options.DryRun,
+			}
+			defaultUser = payload.Options.UserId
+
+		case *taskpb.ImportTasksRequest_Task:
+			index++
+			task, err := protoTaskToDomain(payload.Task)
+			if err == nil {
+				if task.UserID == "" {
+					task.UserID = resolveUserID(ctx, defaultUser)
+				}
+				_, err = h.taskService.ImportTask(ctx, task, opts)
+			}
+			// Si vence el deadline no tiene sentido seguir con el resto
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+
+			switch {
+			case err == nil:
+				summary.Created++
+			case errors.Is(err, domain.ErrTaskExists):
+				summary.Skipped++
+			default:
+				summary.Failed++
+				if len(summary.Errors) < maxImportErrors {
+					summary.Errors = append(summary.Errors, &taskpb.ImportError{
+						Index:   index,
+						TaskId:  payload.Task.GetId(),
+						Message: err.Error(),
+					})
+				}
+			}
+
+		default:
+			return status.Error(codes.InvalidArgument, "import message without options or task")
+		}
+	}
+}
+
+// protoTaskToDomain converts an exported taskpb.Task to a domain.Task. The
+// ID and the timestamps are optional.
+func protoTaskToDomain(task *taskpb.Task) (*domain.Task, error) {
+	result := &domain.Task{
+		UserID:      task.UserId,
+		Title:       task.Title,
+		Description: task.GetDescription(),
+		Completed:   task.Completed,
+	}
+
+	if task.Id != "" {
+		id, err := uuid.FromString(task.Id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id format: %w", err)
+		}
+		result.ID = id
+	}
+	if task.CreatedAt != nil {
+		if err := task.CreatedAt.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid created_at: %w", err)
+		}
+		result.CreatedAt = task.CreatedAt.AsTime()
+	}
+	if task.UpdatedAt != nil {
+		if err := task.UpdatedAt.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid updated_at: %w", err)
+		}
+		result.UpdatedAt = task.UpdatedAt.AsTime()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"unknown error", errors.New("boom"), codes.Internal},
+		{"permission denied", fmt.Errorf("share: %w", domain.ErrPermissionDenied), codes.PermissionDenied},
+		{"task not found", fmt.Errorf("%w: 42", domain.ErrTaskNotFound), codes.NotFound},
+		{"collaborator not found", fmt.Errorf("%w: bob on task 42", domain.ErrCollaboratorNotFound), codes.NotFound},
+		{"filter changed", domain.ErrFilterChanged, codes.FailedPrecondition},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(toStatusError(tt.err, codes.Internal, "failed")); got != tt.want {
+				t.Errorf("code = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/gofrs/uuid"
+)
+
+// maxPageSize bounds page_size so a single response stays reasonably small.
+const maxPageSize = 1000
+
+// Tasks are paginated by creation time and ID, so a page token stays valid
+// while tasks are added or removed between calls.
+
+func encodePageToken(cursor domain.PageCursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + ":" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (domain.PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.PageCursor{}, fmt.Errorf("invalid page_token")
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return domain.PageCursor{}, fmt.Errorf("invalid page_token")
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return domain.PageCursor{}, fmt.Errorf("invalid page_token")
+	}
+	taskID, err := uuid.FromString(id)
+	if err != nil {
+		return domain.PageCursor{}, fmt.Errorf("invalid page_token")
+	}
+
+	return domain.PageCursor{CreatedAt: time.Unix(0, unixNano), ID: taskID}, nil
+}
+
+// pageRequest converts the pagination fields of a list request. A page size
+// of zero returns every remaining task, which keeps older clients working.
+// One task more than the page size is requested to know whether another
+// page follows.
+func pageRequest(pageSize int32, pageToken string) (domain.Page, error) {
+	if pageSize < 0 {
+		return domain.Page{}, fmt.Errorf("page_size must not be negative")
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	var page domain.Page
+	if pageSize > 0 {
+		page.Limit = int(pageSize) + 1
+	}
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken)
+		if err != nil {
+			return domain.Page{}, err
+		}
+		page.After = &cursor
+	}
+	return page, nil
+}
+
+// pageResult drops the extra task requested by pageRequest and returns the
+// token of the next page, empty on the last one.
+func pageResult(tasks []*domain.Task, page domain.Page) ([]*domain.Task, string) {
+	if page.Limit == 0 || len(tasks) < page.Limit {
+		return tasks, ""
+	}
+
+	tasks = tasks[:page.Limit-1]
+	last := tasks[len(tasks)-1]
+	return tasks, encodePageToken(domain.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+}
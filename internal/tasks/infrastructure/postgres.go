@@ -5,17 +5,19 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/Mayer-04/grpc-task-manager-go/internal/database"
 	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const taskColumns = "id, user_id, title, description, completed, created_at, updated_at"
+
 type TaskRepositoryImpl struct {
-	dbpool *pgxpool.Pool
+	dbpool database.Querier
 }
 
-func NewTaskRepository(dbPool *pgxpool.Pool) domain.TaskRepository {
+func NewTaskRepository(dbPool database.Querier) domain.TaskRepository {
 	return &TaskRepositoryImpl{
 		dbpool: dbPool,
 	}
@@ -50,35 +52,8 @@ func (t *TaskRepositoryImpl) CreateTask(ctx context.Context, task *domain.Task)
 	return result, nil
 }
 
-func (r *TaskRepositoryImpl) ListAllTasks(ctx context.Context) ([]*domain.Task, error) {
-	const query = `
-		SELECT id, user_id, title, description, completed, created_at, updated_at 
-		FROM tasks;`
-
-	rows, err := r.dbpool.Query(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve tasks: %w", err)
-	}
-	defer rows.Close()
-
-	var tasks []*domain.Task
-	for rows.Next() {
-		var task domain.Task
-		if err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Title,
-			&task.Description,
-			&task.Completed,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
-		}
-		tasks = append(tasks, &task)
-	}
-
-	return tasks, nil
+func (r *TaskRepositoryImpl) ListAllTasks(ctx context.Context, page domain.Page) ([]*domain.Task, error) {
+	return r.listTasks(ctx, "TRUE", nil, page)
 }
 
 func (r *TaskRepositoryImpl) GetTask(ctx context.Context, taskID string) (*domain.Task, error) {
@@ -99,7 +74,7 @@ func (r *TaskRepositoryImpl) GetTask(ctx context.Context, taskID string) (*domai
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("task not found: %w", err)
+			return nil, fmt.Errorf("%w: %s", domain.ErrTaskNotFound, taskID)
 		}
 		return nil, fmt.Errorf("failed to retrieve task: %w", err)
 	}
@@ -142,19 +117,35 @@ func (r *TaskRepositoryImpl) DeleteTask(ctx context.Context, id string) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("task not found")
+		return fmt.Errorf("%w: %s", domain.ErrTaskNotFound, id)
 	}
 
 	return nil
 }
 
-func (r *TaskRepositoryImpl) ListTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error) {
-	const query = `
-		SELECT id, user_id, title, description, completed, created_at, updated_at 
-		FROM tasks 
-		WHERE user_id = $1;`
+func (r *TaskRepositoryImpl) ListTasksByUser(ctx context.Context, userID string, includeShared bool, page domain.Page) ([]*domain.Task, error) {
+	where := "user_id = $1"
+	if includeShared {
+		where = "(user_id = $1 OR id IN (SELECT task_id FROM task_collaborators WHERE user_id = $1))"
+	}
+	return r.listTasks(ctx, where, []any{userID}, page)
+}
 
-	rows, err := r.dbpool.Query(ctx, query, userID)
+// listTasks returns the page of the tasks matching where, whose arguments
+// are args. The cursor and the limit go in the query, so a page reads only
+// its own rows through the (created_at, id) index.
+func (r *TaskRepositoryImpl) listTasks(ctx context.Context, where string, args []any, page domain.Page) ([]*domain.Task, error) {
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		where += fmt.Sprintf(" AND (created_at, id) > ($%d, $%d)", len(args)-1, len(args))
+	}
+	query := "SELECT " + taskColumns + " FROM tasks WHERE " + where + " ORDER BY created_at, id"
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.dbpool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
@@ -162,19 +153,11 @@ func (r *TaskRepositoryImpl) ListTasksByUser(ctx context.Context, userID string)
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		var task domain.Task
-		if err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Title,
-			&task.Description,
-			&task.Completed,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
 		}
-		tasks = append(tasks, &task)
+		tasks = append(tasks, task)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -209,3 +192,34 @@ func (r *TaskRepositoryImpl) MarkTaskComplete(ctx context.Context, id string) (*
 
 	return task, nil
 }
+
+func (r *TaskRepositoryImpl) CountTasksByStatus(ctx context.Context) (int64, int64, error) {
+	const query = `
+		SELECT
+			COUNT(*) FILTER (WHERE NOT completed),
+			COUNT(*) FILTER (WHERE completed)
+		FROM tasks;`
+
+	var open, completed int64
+	if err := r.dbpool.QueryRow(ctx, query).Scan(&open, &completed); err != nil {
+		return 0, 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	return open, completed, nil
+}
+
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	var task domain.Task
+	if err := row.Scan(
+		&task.ID,
+		&task.UserID,
+		&task.Title,
+		&task.Description,
+		&task.Completed,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan task: %w", err)
+	}
+	return &task, nil
+}
@@ -0,0 +1,159 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/database"
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// filterClause builds the WHERE clause of filter, with its arguments.
+func filterClause(filter domain.TaskFilter) (string, []any) {
+	conditions := []string{"user_id = $1"}
+	args := []any{filter.UserID}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	for _, tag := range filter.Tags {
+		// La etiqueta es una palabra completa del título precedida de +, @ o #
+		add("title ~* $%d", `(^|\s)[+@#]`+regexp.QuoteMeta(tag)+`(\s|$)`)
+	}
+	if filter.Completed != nil {
+		add("completed = $%d", *filter.Completed)
+	}
+	if filter.TitleContains != "" {
+		add("title ILIKE '%%' || $%d::text || '%%'", likeEscaper.Replace(filter.TitleContains))
+	}
+	if filter.CreatedBefore != nil {
+		add("created_at < $%d", *filter.CreatedBefore)
+	}
+	if filter.CreatedAfter != nil {
+		add("created_at >= $%d", *filter.CreatedAfter)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *TaskRepositoryImpl) ListTasksMatching(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error) {
+	where, args := filterClause(filter)
+	query := "SELECT " + taskColumns + " FROM tasks WHERE " + where + " ORDER BY created_at, id;"
+
+	rows, err := r.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *TaskRepositoryImpl) CompleteTasksMatching(ctx context.Context, filter domain.TaskFilter, expected []string, progress domain.BulkProgress) (int, error) {
+	const query = `
+		UPDATE tasks
+		SET completed = true, updated_at = NOW()
+		WHERE id = ANY($1::uuid[])
+		RETURNING ` + taskColumns + `;`
+
+	// Las tareas ya completadas no cambian
+	completed := false
+	filter.Completed = &completed
+	return r.applyMatching(ctx, filter, expected, query, progress)
+}
+
+func (r *TaskRepositoryImpl) DeleteTasksMatching(ctx context.Context, filter domain.TaskFilter, expected []string, progress domain.BulkProgress) (int, error) {
+	const query = `
+		DELETE FROM tasks
+		WHERE id = ANY($1::uuid[])
+		RETURNING ` + taskColumns + `;`
+
+	return r.applyMatching(ctx, filter, expected, query, progress)
+}
+
+// applyMatching locks the tasks selected by filter and runs query, which
+// receives their IDs, in the same transaction.
+func (r *TaskRepositoryImpl) applyMatching(ctx context.Context, filter domain.TaskFilter, expected []string, query string, progress domain.BulkProgress) (int, error) {
+	var processed int
+	err := database.InTx(ctx, r.dbpool, func(tx pgx.Tx) error {
+		where, args := filterClause(filter)
+		// FOR UPDATE impide que otra transacción cambie la selección a mitad
+		rows, err := tx.Query(ctx, "SELECT id FROM tasks WHERE "+where+" ORDER BY created_at, id FOR UPDATE;", args...)
+		if err != nil {
+			return fmt.Errorf("failed to select tasks: %w", err)
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return fmt.Errorf("failed to select tasks: %w", err)
+		}
+
+		if expected != nil && !sameIDs(ids, expected) {
+			return fmt.Errorf("%w: expected %d tasks, found %d", domain.ErrFilterChanged, len(expected), len(ids))
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		rows, err = tx.Query(ctx, query, ids)
+		if err != nil {
+			return fmt.Errorf("failed to update tasks: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			task, err := scanTask(rows)
+			if err != nil {
+				return err
+			}
+			processed++
+			if err := progress(task, len(ids)); err != nil {
+				return err
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to update tasks: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return processed, nil
+}
+
+// sameIDs reports whether ids and expected hold the same set of IDs.
+func sameIDs(ids, expected []string) bool {
+	if len(ids) != len(expected) {
+		return false
+	}
+	set := make(map[string]struct{}, len(expected))
+	for _, id := range expected {
+		set[id] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+	}
+	// Con IDs repetidos en expected el conjunto es menor que ids
+	return len(set) == len(ids)
+}
@@ -0,0 +1,23 @@
+package infrastructure
+
+import "testing"
+
+func TestSameIDs(t *testing.T) {
+	tests := []struct {
+		ids, expected []string
+		want          bool
+	}{
+		{nil, []string{}, true},
+		{[]string{"a", "b"}, []string{"b", "a"}, true},
+		{[]string{"a", "b"}, []string{"a"}, false},
+		{[]string{"a"}, []string{"a", "b"}, false},
+		{[]string{"a", "b"}, []string{"a", "c"}, false},
+		{[]string{"a", "b"}, []string{"a", "a"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := sameIDs(tt.ids, tt.expected); got != tt.want {
+			t.Errorf("sameIDs(%q, %q) = %t, want %t", tt.ids, tt.expected, got, tt.want)
+		}
+	}
+}
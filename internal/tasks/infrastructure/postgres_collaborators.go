@@ -0,0 +1,99 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *TaskRepositoryImpl) ShareTask(ctx context.Context, collaborator *domain.Collaborator) (*domain.Collaborator, error) {
+	const query = `
+		INSERT INTO task_collaborators (task_id, user_id, permission)
+			VALUES ($1, $2, $3)
+		ON CONFLICT (task_id, user_id) DO UPDATE SET permission = EXCLUDED.permission
+		RETURNING task_id, user_id, permission, created_at;
+	`
+
+	result := &domain.Collaborator{}
+	err := r.dbpool.QueryRow(ctx, query, collaborator.TaskID, collaborator.UserID, collaborator.Permission).Scan(
+		&result.TaskID,
+		&result.UserID,
+		&result.Permission,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to share task: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *TaskRepositoryImpl) UnshareTask(ctx context.Context, taskID, userID string) error {
+	const query = "DELETE FROM task_collaborators WHERE task_id = $1 AND user_id = $2;"
+
+	result, err := r.dbpool.Exec(ctx, query, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("could not unshare task: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s on task %s", domain.ErrCollaboratorNotFound, userID, taskID)
+	}
+
+	return nil
+}
+
+func (r *TaskRepositoryImpl) ListCollaborators(ctx context.Context, taskID string) ([]*domain.Collaborator, error) {
+	const query = `
+		SELECT task_id, user_id, permission, created_at
+		FROM task_collaborators
+		WHERE task_id = $1
+		ORDER BY created_at;`
+
+	rows, err := r.dbpool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collaborators: %w", err)
+	}
+	defer rows.Close()
+
+	var collaborators []*domain.Collaborator
+	for rows.Next() {
+		var collaborator domain.Collaborator
+		if err := rows.Scan(
+			&collaborator.TaskID,
+			&collaborator.UserID,
+			&collaborator.Permission,
+			&collaborator.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan collaborator: %w", err)
+		}
+		collaborators = append(collaborators, &collaborator)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating collaborators: %w", err)
+	}
+
+	return collaborators, nil
+}
+
+func (r *TaskRepositoryImpl) GetCollaboratorPermission(ctx context.Context, taskID, userID string) (domain.Permission, error) {
+	const query = `
+		SELECT permission
+		FROM task_collaborators
+		WHERE task_id = $1 AND user_id = $2;`
+
+	var permission domain.Permission
+	err := r.dbpool.QueryRow(ctx, query, taskID, userID).Scan(&permission)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to retrieve collaborator permission: %w", err)
+	}
+
+	return permission, nil
+}
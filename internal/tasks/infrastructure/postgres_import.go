@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Mayer-04/grpc-task-manager-go/internal/tasks/domain"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *TaskRepositoryImpl) ImportTask(ctx context.Context, task *domain.Task, preserveID, preserveTimestamps bool) (*domain.Task, error) {
+	taskID := task.ID
+	if !preserveID || taskID == uuid.Nil {
+		var err error
+		if taskID, err = uuid.NewV4(); err != nil {
+			return nil, fmt.Errorf("failed to generate UUID: %w", err)
+		}
+	}
+
+	// Las fechas nulas toman NOW(), igual que en CreateTask
+	var createdAt, updatedAt any
+	if preserveTimestamps {
+		if !task.CreatedAt.IsZero() {
+			createdAt = task.CreatedAt
+		}
+		if !task.UpdatedAt.IsZero() {
+			updatedAt = task.UpdatedAt
+		}
+	}
+
+	const query = `
+		INSERT INTO tasks (id, user_id, title, description, completed, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, COALESCE($6, NOW()), COALESCE($7, $6, NOW()))
+		ON CONFLICT (id) DO NOTHING
+		RETURNING id, user_id, title, description, completed, created_at, updated_at;
+	`
+
+	result := &domain.Task{}
+	err := r.dbpool.QueryRow(ctx, query, taskID, task.UserID, task.Title, task.Description, task.Completed, createdAt, updatedAt).Scan(
+		&result.ID,
+		&result.UserID,
+		&result.Title,
+		&result.Description,
+		&result.Completed,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrTaskExists, taskID)
+		}
+		return nil, fmt.Errorf("failed to import task: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *TaskRepositoryImpl) TaskExists(ctx context.Context, id string) (bool, error) {
+	const query = "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1);"
+
+	var exists bool
+	if err := r.dbpool.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check task: %w", err)
+	}
+	return exists, nil
+}
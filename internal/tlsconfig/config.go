@@ -0,0 +1,99 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// ClientAuth controls how the server treats client certificates.
+type ClientAuth string
+
+const (
+	ClientAuthNone     ClientAuth = "none"
+	ClientAuthOptional ClientAuth = "optional"
+	ClientAuthRequire  ClientAuth = "require"
+)
+
+// ServerConfig builds a TLS configuration backed by the reloader, so new
+// handshakes always pick up the latest certificate and CA bundle.
+func ServerConfig(r *Reloader, clientAuth ClientAuth) (*tls.Config, error) {
+	var authType tls.ClientAuthType
+	switch clientAuth {
+	case ClientAuthNone, "":
+		authType = tls.NoClientCert
+	case ClientAuthOptional:
+		authType = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		authType = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("invalid client auth mode %q", clientAuth)
+	}
+	if authType != tls.NoClientCert && r.CAPool() == nil {
+		return nil, fmt.Errorf("client certificate verification requires a CA bundle")
+	}
+
+	// NextProtos se copia para que el ALPN configurado por el llamador (h2
+	// para HTTP) se mantenga en la configuración de cada conexión
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{*r.Certificate()},
+			ClientAuth:   authType,
+			ClientCAs:    r.CAPool(),
+			NextProtos:   cfg.NextProtos,
+		}, nil
+	}
+	return cfg, nil
+}
+
+// ClientOptions describes the TLS settings of a gRPC client.
+type ClientOptions struct {
+	CAFile     string // CA para verificar al servidor (por defecto, las del sistema)
+	CertFile   string // certificado de cliente para mTLS
+	KeyFile    string
+	ServerName string
+	Insecure   bool // omite la verificación del servidor, solo para desarrollo
+}
+
+func ClientConfig(opts ClientOptions) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.Insecure,
+	}
+
+	if opts.CAFile != "" {
+		pool, err := LoadCAPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// IdentityFromCertificate returns the subject used to identify a client
+// certificate: the Common Name, or the first DNS/email SAN when it is empty.
+func IdentityFromCertificate(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses[0]
+	}
+	return ""
+}
@@ -0,0 +1,236 @@
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testPair is a self-signed certificate and its key, PEM encoded.
+type testPair struct {
+	der     []byte
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestPair(t *testing.T, commonName string) testPair {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testPair{
+		der:     der,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeFile writes data to path and moves its modification time forward,
+// so the change is seen even on filesystems with coarse timestamps.
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// servedCertificate returns the leaf that a new handshake would present.
+func servedCertificate(t *testing.T, cfg *tls.Config) []byte {
+	t.Helper()
+	connCfg, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return connCfg.Certificates[0].Certificate[0]
+}
+
+func TestReloaderServesRewrittenCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	old, renewed := newTestPair(t, "old"), newTestPair(t, "renewed")
+	start := time.Now().Add(-time.Hour)
+	writeFile(t, certFile, old.certPEM, start)
+	writeFile(t, keyFile, old.keyPEM, start)
+
+	reloader, err := NewReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ServerConfig(reloader, ClientAuthNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(servedCertificate(t, cfg), old.der) {
+		t.Fatal("initial certificate is not served")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx, 10*time.Millisecond)
+
+	writeFile(t, certFile, renewed.certPEM, start.Add(time.Minute))
+	writeFile(t, keyFile, renewed.keyPEM, start.Add(time.Minute))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !bytes.Equal(servedCertificate(t, cfg), renewed.der) {
+		if time.Now().After(deadline) {
+			t.Fatal("rewritten certificate was not served")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReloaderKeepsMaterialOfHalfWrittenPair(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	old, renewed := newTestPair(t, "old"), newTestPair(t, "renewed")
+	start := time.Now().Add(-time.Hour)
+	writeFile(t, certFile, old.certPEM, start)
+	writeFile(t, keyFile, old.keyPEM, start)
+
+	reloader, err := NewReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// El certificado nuevo llega antes que su clave
+	writeFile(t, certFile, renewed.certPEM, start.Add(time.Minute))
+	if err := reloader.reload(); err == nil {
+		t.Fatal("reload of a mismatched pair succeeded")
+	}
+	if !bytes.Equal(reloader.Certificate().Certificate[0], old.der) {
+		t.Fatal("half-written pair replaced the previous certificate")
+	}
+
+	// Un archivo truncado a medio escribir tampoco se carga
+	writeFile(t, keyFile, renewed.keyPEM[:len(renewed.keyPEM)/2], start.Add(2*time.Minute))
+	if err := reloader.reload(); err == nil {
+		t.Fatal("reload of a truncated key succeeded")
+	}
+	if !bytes.Equal(reloader.Certificate().Certificate[0], old.der) {
+		t.Fatal("truncated key replaced the previous certificate")
+	}
+
+	// Cuando la clave se completa se carga el par nuevo
+	writeFile(t, keyFile, renewed.keyPEM, start.Add(3*time.Minute))
+	if err := reloader.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reloader.Certificate().Certificate[0], renewed.der) {
+		t.Error("complete pair was not loaded")
+	}
+}
+
+func TestServerConfigClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	pair := newTestPair(t, "server")
+	certFile, keyFile, caFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.crt")
+	now := time.Now()
+	writeFile(t, certFile, pair.certPEM, now)
+	writeFile(t, keyFile, pair.keyPEM, now)
+	writeFile(t, caFile, pair.certPEM, now)
+
+	withoutCA, err := NewReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	withCA, err := NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		reloader *Reloader
+		mode     ClientAuth
+		want     tls.ClientAuthType
+		wantErr  bool
+	}{
+		{"default", withoutCA, "", tls.NoClientCert, false},
+		{"none", withoutCA, ClientAuthNone, tls.NoClientCert, false},
+		{"optional", withCA, ClientAuthOptional, tls.VerifyClientCertIfGiven, false},
+		{"require", withCA, ClientAuthRequire, tls.RequireAndVerifyClientCert, false},
+		{"require without CA", withoutCA, ClientAuthRequire, 0, true},
+		{"unknown mode", withCA, "sometimes", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ServerConfig(tt.reloader, tt.mode)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ServerConfig error = nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg.NextProtos = []string{"h2"}
+			connCfg, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if connCfg.ClientAuth != tt.want || connCfg.MinVersion != tls.VersionTLS12 || len(connCfg.NextProtos) != 1 {
+				t.Errorf("config = %v, min %x, protos %v; want %v, TLS 1.2, [h2]", connCfg.ClientAuth, connCfg.MinVersion, connCfg.NextProtos, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentityFromCertificate(t *testing.T) {
+	tests := []struct {
+		name string
+		cert *x509.Certificate
+		want string
+	}{
+		{"common name", &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}, DNSNames: []string{"alice.example.com"}}, "alice"},
+		{"dns name", &x509.Certificate{DNSNames: []string{"worker.example.com", "other.example.com"}, EmailAddresses: []string{"ops@example.com"}}, "worker.example.com"},
+		{"email", &x509.Certificate{EmailAddresses: []string{"ops@example.com"}}, "ops@example.com"},
+		{"no identity", &x509.Certificate{Subject: pkix.Name{Organization: []string{"Example"}}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IdentityFromCertificate(tt.cert); got != tt.want {
+				t.Errorf("IdentityFromCertificate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
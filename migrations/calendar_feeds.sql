@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner_id VARCHAR(255) NOT NULL,
+    include_shared BOOLEAN NOT NULL DEFAULT FALSE,
+    prefix VARCHAR(16) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_feeds_owner_id ON calendar_feeds (owner_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS task_collaborators (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    permission VARCHAR(16) NOT NULL CHECK (permission IN ('read', 'write')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_collaborators_user_id ON task_collaborators (user_id);
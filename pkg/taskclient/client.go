@@ -0,0 +1,196 @@
+// Package taskclient is the Go client for the task manager gRPC API.
+//
+//	client, err := taskclient.New(
+//		taskclient.WithAddress("tasks.example.com:443"),
+//		taskclient.WithTLS(&tls.Config{}),
+//		taskclient.WithToken(token),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//
+//	for task, err := range client.ListTasksByUser(ctx, "") {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(task.Title)
+//	}
+//
+// Calls failing with Unavailable are retried with backoff through the gRPC
+// service config, and every failure is returned as an *Error.
+package taskclient
+
+import (
+	"context"
+	"fmt"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client calls TaskService. It is safe for concurrent use.
+type Client struct {
+	conn    *grpc.ClientConn
+	tasks   taskpb.TaskServiceClient
+	options options
+}
+
+// New creates a client. The connection is established lazily on the first
+// call, so New only fails on invalid options.
+func New(opts ...Option) (*Client, error) {
+	o := options{
+		address: DefaultAddress,
+		timeout: DefaultTimeout,
+		retry:   DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.retry = o.retry.withDefaults()
+
+	c := &Client{options: o}
+
+	transportCreds := insecure.NewCredentials()
+	if o.tls != nil {
+		transportCreds = credentials.NewTLS(o.tls)
+	}
+	config, err := serviceConfig(o.retry)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithDefaultServiceConfig(config),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+	}
+	if o.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials{token: o.token}))
+	}
+	if o.apiKey != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(apiKeyCredentials{key: o.apiKey}))
+	}
+	dialOpts = append(dialOpts, o.dialOptions...)
+
+	conn, err := grpc.NewClient(o.address, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", o.address, err)
+	}
+
+	c.conn = conn
+	c.tasks = taskpb.NewTaskServiceClient(conn)
+	return c, nil
+}
+
+// withDefaults fills the unset backoff parameters from DefaultRetryPolicy,
+// since gRPC rejects a retry policy without them.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryPolicy.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	if p.BackoffMultiplier <= 0 {
+		p.BackoffMultiplier = DefaultRetryPolicy.BackoffMultiplier
+	}
+	return p
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// TaskService returns the generated client sharing this connection, for
+// calls not wrapped by Client. It keeps the timeout and typed errors.
+func (c *Client) TaskService() taskpb.TaskServiceClient {
+	return c.tasks
+}
+
+// Conn returns the underlying connection, to create clients of the other
+// services of the server (ApiKeyService, CalendarService) sharing it.
+func (c *Client) Conn() grpc.ClientConnInterface {
+	return c.conn
+}
+
+// unaryInterceptor applies the default timeout and converts failures into
+// *Error, including the request ID sent by the server.
+func (c *Client) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && c.options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.options.timeout)
+		defer cancel()
+	}
+
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	return newError(err, header)
+}
+
+func (c *Client) CreateTask(ctx context.Context, req *taskpb.CreateTaskRequest) (*taskpb.Task, error) {
+	resp, err := c.tasks.CreateTask(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+func (c *Client) GetTask(ctx context.Context, id string) (*taskpb.Task, error) {
+	resp, err := c.tasks.GetTask(ctx, &taskpb.GetTaskRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// UpdateTask changes the fields set in req and leaves the others untouched.
+func (c *Client) UpdateTask(ctx context.Context, req *taskpb.UpdateTaskRequest) (*taskpb.Task, error) {
+	resp, err := c.tasks.UpdateTask(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	_, err := c.tasks.DeleteTask(ctx, &taskpb.DeleteTaskRequest{Id: id})
+	return err
+}
+
+func (c *Client) MarkTaskComplete(ctx context.Context, id string) (*taskpb.Task, error) {
+	resp, err := c.tasks.MarkTaskComplete(ctx, &taskpb.MarkTaskCompleteRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+func (c *Client) ShareTask(ctx context.Context, taskID, userID string, permission taskpb.Permission) (*taskpb.Collaborator, error) {
+	resp, err := c.tasks.ShareTask(ctx, &taskpb.ShareTaskRequest{
+		TaskId:     taskID,
+		UserId:     userID,
+		Permission: permission,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Collaborator, nil
+}
+
+func (c *Client) UnshareTask(ctx context.Context, taskID, userID string) error {
+	_, err := c.tasks.UnshareTask(ctx, &taskpb.UnshareTaskRequest{TaskId: taskID, UserId: userID})
+	return err
+}
+
+func (c *Client) ListCollaborators(ctx context.Context, taskID string) ([]*taskpb.Collaborator, error) {
+	resp, err := c.tasks.ListCollaborators(ctx, &taskpb.ListCollaboratorsRequest{TaskId: taskID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Collaborators, nil
+}
@@ -0,0 +1,79 @@
+package taskclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+)
+
+// tokenCredentials attaches a bearer token to every RPC.
+type tokenCredentials struct {
+	token string
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// apiKeyCredentials attaches an API key to every RPC.
+type apiKeyCredentials struct {
+	key string
+}
+
+func (a apiKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"x-api-key": a.key}, nil
+}
+
+func (a apiKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// serviceConfig returns the gRPC service config with the retry policy for
+// TaskService, or an empty config when retries are disabled.
+func serviceConfig(policy RetryPolicy) (string, error) {
+	if policy.MaxAttempts < 2 {
+		return "{}", nil
+	}
+
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+	type methodConfig struct {
+		Name        []map[string]string `json:"name"`
+		RetryPolicy retryPolicy         `json:"retryPolicy"`
+	}
+
+	data, err := json.Marshal(map[string][]methodConfig{
+		"methodConfig": {{
+			Name: []map[string]string{{"service": taskpb.TaskService_ServiceDesc.ServiceName}},
+			RetryPolicy: retryPolicy{
+				MaxAttempts:          policy.MaxAttempts,
+				InitialBackoff:       durationJSON(policy.InitialBackoff),
+				MaxBackoff:           durationJSON(policy.MaxBackoff),
+				BackoffMultiplier:    policy.BackoffMultiplier,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode service config: %w", err)
+	}
+	return string(data), nil
+}
+
+// durationJSON formats d as a protobuf JSON duration, e.g. "0.1s".
+func durationJSON(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,94 @@
+package taskclient
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Sentinel errors for errors.Is. They match any *Error with the same code:
+//
+//	if errors.Is(err, taskclient.ErrNotFound) { ... }
+var (
+	ErrInvalidArgument   = &Error{Code: codes.InvalidArgument}
+	ErrNotFound          = &Error{Code: codes.NotFound}
+	ErrAlreadyExists     = &Error{Code: codes.AlreadyExists}
+	ErrPermissionDenied  = &Error{Code: codes.PermissionDenied}
+	ErrUnauthenticated   = &Error{Code: codes.Unauthenticated}
+	ErrResourceExhausted = &Error{Code: codes.ResourceExhausted}
+	ErrDeadlineExceeded  = &Error{Code: codes.DeadlineExceeded}
+	ErrUnavailable       = &Error{Code: codes.Unavailable}
+	ErrInternal          = &Error{Code: codes.Internal}
+)
+
+// Error is the error returned by every call that fails with a gRPC status.
+type Error struct {
+	Code    codes.Code
+	Message string
+	// RequestID is the x-request-id assigned by the server, useful to find
+	// the call in the server logs.
+	RequestID string
+	// RetryAfter is the delay suggested by the server when rate limited.
+	RetryAfter time.Duration
+
+	status *status.Status
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Code.String()
+	}
+	return e.Code.String() + ": " + e.Message
+}
+
+// Is reports whether target is a sentinel with the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.status == nil && t.Code == e.Code
+}
+
+// GRPCStatus lets status.FromError and status.Code see through the error.
+func (e *Error) GRPCStatus() *status.Status {
+	if e.status == nil {
+		return status.New(e.Code, e.Message)
+	}
+	return e.status
+}
+
+// newError converts a gRPC status error into an *Error. Other errors are
+// returned unchanged.
+func newError(err error, header metadata.MD) error {
+	if err == nil {
+		return nil
+	}
+	var typed *Error
+	if errors.As(err, &typed) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	e := &Error{
+		Code:    st.Code(),
+		Message: st.Message(),
+		status:  st,
+	}
+	if values := header.Get("x-request-id"); len(values) > 0 {
+		e.RequestID = values[0]
+		// El servidor añade el ID al mensaje; ya está disponible en RequestID
+		e.Message = strings.TrimSuffix(e.Message, " (request_id="+e.RequestID+")")
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			e.RetryAfter = info.GetRetryDelay().AsDuration()
+		}
+	}
+	return e
+}
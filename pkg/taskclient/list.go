@@ -0,0 +1,99 @@
+package taskclient
+
+import (
+	"context"
+	"iter"
+
+	taskpb "github.com/Mayer-04/grpc-task-manager-go/pkg/taskpb"
+)
+
+// DefaultPageSize is the number of tasks requested per page.
+const DefaultPageSize = 100
+
+type listOptions struct {
+	pageSize      int32
+	includeShared bool
+}
+
+// ListOption configures the list calls.
+type ListOption func(*listOptions)
+
+// WithPageSize sets how many tasks are fetched per call. The server caps
+// the size of a page.
+func WithPageSize(size int32) ListOption {
+	return func(o *listOptions) { o.pageSize = size }
+}
+
+// IncludeShared also lists the tasks shared with the user.
+func IncludeShared() ListOption {
+	return func(o *listOptions) { o.includeShared = true }
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	o := listOptions{pageSize: DefaultPageSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// ListTasksByUser iterates over the tasks of a user, fetching one page at a
+// time. An empty userID lists the tasks of the authenticated caller. The
+// iteration stops after yielding the first error.
+func (c *Client) ListTasksByUser(ctx context.Context, userID string, opts ...ListOption) iter.Seq2[*taskpb.Task, error] {
+	o := newListOptions(opts)
+	return paginate(func(pageToken string) (*taskpb.ListTasksResponse, error) {
+		return c.tasks.ListTasksByUser(ctx, &taskpb.ListTasksByUserRequest{
+			UserId:        userID,
+			IncludeShared: o.includeShared,
+			PageSize:      o.pageSize,
+			PageToken:     pageToken,
+		})
+	})
+}
+
+// ListAllTasks iterates over the tasks of every user. It requires the
+// admin role.
+func (c *Client) ListAllTasks(ctx context.Context, opts ...ListOption) iter.Seq2[*taskpb.Task, error] {
+	o := newListOptions(opts)
+	return paginate(func(pageToken string) (*taskpb.ListTasksResponse, error) {
+		return c.tasks.ListAllTasks(ctx, &taskpb.ListAllTasksRequest{
+			PageSize:  o.pageSize,
+			PageToken: pageToken,
+		})
+	})
+}
+
+// Collect drains a list iterator into a slice.
+func Collect(seq iter.Seq2[*taskpb.Task, error]) ([]*taskpb.Task, error) {
+	var tasks []*taskpb.Task
+	for task, err := range seq {
+		if err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func paginate(fetch func(pageToken string) (*taskpb.ListTasksResponse, error)) iter.Seq2[*taskpb.Task, error] {
+	return func(yield func(*taskpb.Task, error) bool) {
+		pageToken := ""
+		for {
+			resp, err := fetch(pageToken)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, task := range resp.Tasks {
+				if !yield(task, nil) {
+					return
+				}
+			}
+			if resp.NextPageToken == "" {
+				return
+			}
+			pageToken = resp.NextPageToken
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package taskclient
+
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultAddress is the address of a locally running server.
+	DefaultAddress = "localhost:50051"
+	// DefaultTimeout bounds calls whose context has no deadline.
+	DefaultTimeout = 10 * time.Second
+)
+
+// DefaultRetryPolicy retries calls failing with Unavailable, e.g. while the
+// server restarts or a load balancer drains a backend.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       4,
+	InitialBackoff:    100 * time.Millisecond,
+	MaxBackoff:        2 * time.Second,
+	BackoffMultiplier: 2,
+}
+
+// RetryPolicy configures the gRPC retry policy applied to every call. The
+// backoff between attempts is randomized by gRPC between zero and the
+// current backoff.
+type RetryPolicy struct {
+	// MaxAttempts includes the original call; values below 2 disable retries.
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+}
+
+type options struct {
+	address     string
+	tls         *tls.Config
+	token       string
+	apiKey      string
+	timeout     time.Duration
+	retry       RetryPolicy
+	dialOptions []grpc.DialOption
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithAddress sets the server address. Defaults to DefaultAddress.
+func WithAddress(address string) Option {
+	return func(o *options) { o.address = address }
+}
+
+// WithTLS connects using TLS with the given configuration. Without it the
+// connection is not encrypted.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) { o.tls = cfg }
+}
+
+// WithToken sends a JWT as bearer token on every call.
+func WithToken(token string) Option {
+	return func(o *options) { o.token = token }
+}
+
+// WithAPIKey sends an API key in the x-api-key metadata on every call.
+func WithAPIKey(key string) Option {
+	return func(o *options) { o.apiKey = key }
+}
+
+// WithTimeout sets the deadline of calls whose context has none. Zero
+// leaves those calls unbounded. Defaults to DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithRetry replaces DefaultRetryPolicy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) { o.retry = policy }
+}
+
+// WithDialOptions appends raw gRPC dial options, e.g. a stats handler.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, opts...) }
+}
@@ -7,6 +7,7 @@
 package taskpb
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -22,6 +23,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// COLABORADORES
+type Permission int32
+
+const (
+	Permission_PERMISSION_UNSPECIFIED Permission = 0
+	Permission_PERMISSION_READ        Permission = 1
+	Permission_PERMISSION_WRITE       Permission = 2
+)
+
+// Enum value maps for Permission.
+var (
+	Permission_name = map[int32]string{
+		0: "PERMISSION_UNSPECIFIED",
+		1: "PERMISSION_READ",
+		2: "PERMISSION_WRITE",
+	}
+	Permission_value = map[string]int32{
+		"PERMISSION_UNSPECIFIED": 0,
+		"PERMISSION_READ":        1,
+		"PERMISSION_WRITE":       2,
+	}
+)
+
+func (x Permission) Enum() *Permission {
+	p := new(Permission)
+	*p = x
+	return p
+}
+
+func (x Permission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Permission) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_proto_enumTypes[0].Descriptor()
+}
+
+func (Permission) Type() protoreflect.EnumType {
+	return &file_task_proto_enumTypes[0]
+}
+
+func (x Permission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Permission.Descriptor instead.
+func (Permission) EnumDescriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{0}
+}
+
 type Task struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -613,6 +664,9 @@ func (x *MarkTaskCompleteResponse) GetTask() *Task {
 type ListTasksByUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IncludeShared bool                   `protobuf:"varint,2,opt,name=include_shared,json=includeShared,proto3" json:"include_shared,omitempty"` // incluye las tareas compartidas con el usuario
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                // 0 devuelve todas las tareas
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`              // next_page_token de la respuesta anterior
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -654,8 +708,31 @@ func (x *ListTasksByUserRequest) GetUserId() string {
 	return ""
 }
 
+func (x *ListTasksByUserRequest) GetIncludeShared() bool {
+	if x != nil {
+		return x.IncludeShared
+	}
+	return false
+}
+
+func (x *ListTasksByUserRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksByUserRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type ListAllTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -690,9 +767,24 @@ func (*ListAllTasksRequest) Descriptor() ([]byte, []int) {
 	return file_task_proto_rawDescGZIP(), []int{12}
 }
 
+func (x *ListAllTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAllTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type ListTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // vacío en la última página
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -734,153 +826,1325 @@ func (x *ListTasksResponse) GetTasks() []*Task {
 	return nil
 }
 
-var File_task_proto protoreflect.FileDescriptor
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
 
-const file_task_proto_rawDesc = "" +
-	"\n" +
-	"\n" +
-	"task.proto\x12\btasks.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x90\x02\n" +
-	"\x04Task\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05title\x18\x03 \x01(\tR\x05title\x12%\n" +
-	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1c\n" +
-	"\tcompleted\x18\x05 \x01(\bR\tcompleted\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
-	"\f_description\"\xaa\x01\n" +
-	"\x11CreateTaskRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12!\n" +
-	"\tcompleted\x18\x04 \x01(\bH\x01R\tcompleted\x88\x01\x01B\x0e\n" +
-	"\f_descriptionB\f\n" +
-	"\n" +
-	"_completed\"8\n" +
-	"\x12CreateTaskResponse\x12\"\n" +
-	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\" \n" +
-	"\x0eGetTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
-	"\x0fGetTaskResponse\x12\"\n" +
-	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"\xb0\x01\n" +
-	"\x11UpdateTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12!\n" +
-	"\tcompleted\x18\x04 \x01(\bH\x02R\tcompleted\x88\x01\x01B\b\n" +
-	"\x06_titleB\x0e\n" +
-	"\f_descriptionB\f\n" +
-	"\n" +
-	"_completed\"8\n" +
-	"\x12UpdateTaskResponse\x12\"\n" +
-	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"#\n" +
-	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
-	"\x12DeleteTaskResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\")\n" +
-	"\x17MarkTaskCompleteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
-	"\x18MarkTaskCompleteResponse\x12\"\n" +
-	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"1\n" +
-	"\x16ListTasksByUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x15\n" +
-	"\x13ListAllTasksRequest\"9\n" +
-	"\x11ListTasksResponse\x12$\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x0e.tasks.v1.TaskR\x05tasks2\xa1\x04\n" +
-	"\vTaskService\x12G\n" +
-	"\n" +
-	"CreateTask\x12\x1b.tasks.v1.CreateTaskRequest\x1a\x1c.tasks.v1.CreateTaskResponse\x12>\n" +
-	"\aGetTask\x12\x18.tasks.v1.GetTaskRequest\x1a\x19.tasks.v1.GetTaskResponse\x12G\n" +
-	"\n" +
-	"UpdateTask\x12\x1b.tasks.v1.UpdateTaskRequest\x1a\x1c.tasks.v1.UpdateTaskResponse\x12G\n" +
-	"\n" +
-	"DeleteTask\x12\x1b.tasks.v1.DeleteTaskRequest\x1a\x1c.tasks.v1.DeleteTaskResponse\x12Y\n" +
-	"\x10MarkTaskComplete\x12!.tasks.v1.MarkTaskCompleteRequest\x1a\".tasks.v1.MarkTaskCompleteResponse\x12P\n" +
-	"\x0fListTasksByUser\x12 .tasks.v1.ListTasksByUserRequest\x1a\x1b.tasks.v1.ListTasksResponse\x12J\n" +
-	"\fListAllTasks\x12\x1d.tasks.v1.ListAllTasksRequest\x1a\x1b.tasks.v1.ListTasksResponseB5Z3github.com/Mayer-04/grpc-task-manager-go/pkg/taskpbb\x06proto3"
+type Collaborator struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Permission    Permission             `protobuf:"varint,3,opt,name=permission,proto3,enum=tasks.v1.Permission" json:"permission,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-var (
-	file_task_proto_rawDescOnce sync.Once
-	file_task_proto_rawDescData []byte
-)
+func (x *Collaborator) Reset() {
+	*x = Collaborator{}
+	mi := &file_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func file_task_proto_rawDescGZIP() []byte {
-	file_task_proto_rawDescOnce.Do(func() {
-		file_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_task_proto_rawDesc), len(file_task_proto_rawDesc)))
-	})
-	return file_task_proto_rawDescData
+func (x *Collaborator) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_task_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
-var file_task_proto_goTypes = []any{
-	(*Task)(nil),                     // 0: tasks.v1.Task
-	(*CreateTaskRequest)(nil),        // 1: tasks.v1.CreateTaskRequest
-	(*CreateTaskResponse)(nil),       // 2: tasks.v1.CreateTaskResponse
-	(*GetTaskRequest)(nil),           // 3: tasks.v1.GetTaskRequest
-	(*GetTaskResponse)(nil),          // 4: tasks.v1.GetTaskResponse
-	(*UpdateTaskRequest)(nil),        // 5: tasks.v1.UpdateTaskRequest
-	(*UpdateTaskResponse)(nil),       // 6: tasks.v1.UpdateTaskResponse
-	(*DeleteTaskRequest)(nil),        // 7: tasks.v1.DeleteTaskRequest
-	(*DeleteTaskResponse)(nil),       // 8: tasks.v1.DeleteTaskResponse
-	(*MarkTaskCompleteRequest)(nil),  // 9: tasks.v1.MarkTaskCompleteRequest
-	(*MarkTaskCompleteResponse)(nil), // 10: tasks.v1.MarkTaskCompleteResponse
-	(*ListTasksByUserRequest)(nil),   // 11: tasks.v1.ListTasksByUserRequest
-	(*ListAllTasksRequest)(nil),      // 12: tasks.v1.ListAllTasksRequest
-	(*ListTasksResponse)(nil),        // 13: tasks.v1.ListTasksResponse
-	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+func (*Collaborator) ProtoMessage() {}
+
+func (x *Collaborator) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_task_proto_depIdxs = []int32{
-	14, // 0: tasks.v1.Task.created_at:type_name -> google.protobuf.Timestamp
-	14, // 1: tasks.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: tasks.v1.CreateTaskResponse.task:type_name -> tasks.v1.Task
-	0,  // 3: tasks.v1.GetTaskResponse.task:type_name -> tasks.v1.Task
-	0,  // 4: tasks.v1.UpdateTaskResponse.task:type_name -> tasks.v1.Task
-	0,  // 5: tasks.v1.MarkTaskCompleteResponse.task:type_name -> tasks.v1.Task
-	0,  // 6: tasks.v1.ListTasksResponse.tasks:type_name -> tasks.v1.Task
-	1,  // 7: tasks.v1.TaskService.CreateTask:input_type -> tasks.v1.CreateTaskRequest
-	3,  // 8: tasks.v1.TaskService.GetTask:input_type -> tasks.v1.GetTaskRequest
-	5,  // 9: tasks.v1.TaskService.UpdateTask:input_type -> tasks.v1.UpdateTaskRequest
-	7,  // 10: tasks.v1.TaskService.DeleteTask:input_type -> tasks.v1.DeleteTaskRequest
-	9,  // 11: tasks.v1.TaskService.MarkTaskComplete:input_type -> tasks.v1.MarkTaskCompleteRequest
-	11, // 12: tasks.v1.TaskService.ListTasksByUser:input_type -> tasks.v1.ListTasksByUserRequest
-	12, // 13: tasks.v1.TaskService.ListAllTasks:input_type -> tasks.v1.ListAllTasksRequest
-	2,  // 14: tasks.v1.TaskService.CreateTask:output_type -> tasks.v1.CreateTaskResponse
-	4,  // 15: tasks.v1.TaskService.GetTask:output_type -> tasks.v1.GetTaskResponse
-	6,  // 16: tasks.v1.TaskService.UpdateTask:output_type -> tasks.v1.UpdateTaskResponse
-	8,  // 17: tasks.v1.TaskService.DeleteTask:output_type -> tasks.v1.DeleteTaskResponse
-	10, // 18: tasks.v1.TaskService.MarkTaskComplete:output_type -> tasks.v1.MarkTaskCompleteResponse
-	13, // 19: tasks.v1.TaskService.ListTasksByUser:output_type -> tasks.v1.ListTasksResponse
-	13, // 20: tasks.v1.TaskService.ListAllTasks:output_type -> tasks.v1.ListTasksResponse
-	14, // [14:21] is the sub-list for method output_type
-	7,  // [7:14] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+
+// Deprecated: Use Collaborator.ProtoReflect.Descriptor instead.
+func (*Collaborator) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{14}
 }
 
-func init() { file_task_proto_init() }
-func file_task_proto_init() {
-	if File_task_proto != nil {
-		return
+func (x *Collaborator) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
 	}
-	file_task_proto_msgTypes[0].OneofWrappers = []any{}
-	file_task_proto_msgTypes[1].OneofWrappers = []any{}
-	file_task_proto_msgTypes[5].OneofWrappers = []any{}
+	return ""
+}
+
+func (x *Collaborator) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Collaborator) GetPermission() Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return Permission_PERMISSION_UNSPECIFIED
+}
+
+func (x *Collaborator) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ShareTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Permission    Permission             `protobuf:"varint,3,opt,name=permission,proto3,enum=tasks.v1.Permission" json:"permission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTaskRequest) Reset() {
+	*x = ShareTaskRequest{}
+	mi := &file_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTaskRequest) ProtoMessage() {}
+
+func (x *ShareTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTaskRequest.ProtoReflect.Descriptor instead.
+func (*ShareTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ShareTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ShareTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareTaskRequest) GetPermission() Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return Permission_PERMISSION_UNSPECIFIED
+}
+
+type ShareTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collaborator  *Collaborator          `protobuf:"bytes,1,opt,name=collaborator,proto3" json:"collaborator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTaskResponse) Reset() {
+	*x = ShareTaskResponse{}
+	mi := &file_task_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTaskResponse) ProtoMessage() {}
+
+func (x *ShareTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTaskResponse.ProtoReflect.Descriptor instead.
+func (*ShareTaskResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ShareTaskResponse) GetCollaborator() *Collaborator {
+	if x != nil {
+		return x.Collaborator
+	}
+	return nil
+}
+
+type UnshareTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTaskRequest) Reset() {
+	*x = UnshareTaskRequest{}
+	mi := &file_task_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTaskRequest) ProtoMessage() {}
+
+func (x *UnshareTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTaskRequest.ProtoReflect.Descriptor instead.
+func (*UnshareTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnshareTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UnshareTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnshareTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTaskResponse) Reset() {
+	*x = UnshareTaskResponse{}
+	mi := &file_task_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTaskResponse) ProtoMessage() {}
+
+func (x *UnshareTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTaskResponse.ProtoReflect.Descriptor instead.
+func (*UnshareTaskResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnshareTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnshareTaskResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListCollaboratorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollaboratorsRequest) Reset() {
+	*x = ListCollaboratorsRequest{}
+	mi := &file_task_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollaboratorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollaboratorsRequest) ProtoMessage() {}
+
+func (x *ListCollaboratorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollaboratorsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListCollaboratorsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type ListCollaboratorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collaborators []*Collaborator        `protobuf:"bytes,1,rep,name=collaborators,proto3" json:"collaborators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollaboratorsResponse) Reset() {
+	*x = ListCollaboratorsResponse{}
+	mi := &file_task_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollaboratorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollaboratorsResponse) ProtoMessage() {}
+
+func (x *ListCollaboratorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollaboratorsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollaboratorsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListCollaboratorsResponse) GetCollaborators() []*Collaborator {
+	if x != nil {
+		return x.Collaborators
+	}
+	return nil
+}
+
+// IMPORTACIÓN Y EXPORTACIÓN
+type ExportTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`        // vacío: el usuario autenticado
+	AllUsers      bool                   `protobuf:"varint,2,opt,name=all_users,json=allUsers,proto3" json:"all_users,omitempty"` // exporta las tareas de todos los usuarios (admin)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTasksRequest) Reset() {
+	*x = ExportTasksRequest{}
+	mi := &file_task_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTasksRequest) ProtoMessage() {}
+
+func (x *ExportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ExportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ExportTasksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportTasksRequest) GetAllUsers() bool {
+	if x != nil {
+		return x.AllUsers
+	}
+	return false
+}
+
+type ExportTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTasksResponse) Reset() {
+	*x = ExportTasksResponse{}
+	mi := &file_task_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTasksResponse) ProtoMessage() {}
+
+func (x *ExportTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTasksResponse.ProtoReflect.Descriptor instead.
+func (*ExportTasksResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportTasksResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ImportOptions struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PreserveIds        bool                   `protobuf:"varint,1,opt,name=preserve_ids,json=preserveIds,proto3" json:"preserve_ids,omitempty"`                      // conserva el id; los ids existentes se omiten
+	PreserveTimestamps bool                   `protobuf:"varint,2,opt,name=preserve_timestamps,json=preserveTimestamps,proto3" json:"preserve_timestamps,omitempty"` // conserva created_at y updated_at
+	DryRun             bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                     // valida sin escribir
+	UserId             string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                      // dueño de las tareas sin user_id
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportOptions) GetPreserveIds() bool {
+	if x != nil {
+		return x.PreserveIds
+	}
+	return false
+}
+
+func (x *ImportOptions) GetPreserveTimestamps() bool {
+	if x != nil {
+		return x.PreserveTimestamps
+	}
+	return false
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// El primer mensaje puede llevar las opciones; el resto, una tarea cada uno.
+type ImportTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportTasksRequest_Options
+	//	*ImportTasksRequest_Task
+	Payload       isImportTasksRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTasksRequest) Reset() {
+	*x = ImportTasksRequest{}
+	mi := &file_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksRequest) ProtoMessage() {}
+
+func (x *ImportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ImportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportTasksRequest) GetPayload() isImportTasksRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportTasksRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportTasksRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportTasksRequest) GetTask() *Task {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportTasksRequest_Task); ok {
+			return x.Task
+		}
+	}
+	return nil
+}
+
+type isImportTasksRequest_Payload interface {
+	isImportTasksRequest_Payload()
+}
+
+type ImportTasksRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportTasksRequest_Task struct {
+	Task *Task `protobuf:"bytes,2,opt,name=task,proto3,oneof"`
+}
+
+func (*ImportTasksRequest_Options) isImportTasksRequest_Payload() {}
+
+func (*ImportTasksRequest_Task) isImportTasksRequest_Payload() {}
+
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // posición de la tarea en el stream, desde 1
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_task_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportError) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int32                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"` // como máximo 100
+	DryRun        bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTasksResponse) Reset() {
+	*x = ImportTasksResponse{}
+	mi := &file_task_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksResponse) ProtoMessage() {}
+
+func (x *ImportTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksResponse.ProtoReflect.Descriptor instead.
+func (*ImportTasksResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportTasksResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportTasksResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// OPERACIONES POR FILTRO
+// Los campos vacíos no filtran. Solo se seleccionan las tareas propias del
+// usuario, no las compartidas con él.
+type TaskFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // vacío: el usuario autenticado
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`                   // palabras del título con +, @ o #; deben estar todas
+	Completed     *bool                  `protobuf:"varint,3,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	TitleContains string                 `protobuf:"bytes,4,opt,name=title_contains,json=titleContains,proto3" json:"title_contains,omitempty"` // sin distinguir mayúsculas
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskFilter) Reset() {
+	*x = TaskFilter{}
+	mi := &file_task_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFilter) ProtoMessage() {}
+
+func (x *TaskFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFilter.ProtoReflect.Descriptor instead.
+func (*TaskFilter) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TaskFilter) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+func (x *TaskFilter) GetTitleContains() string {
+	if x != nil {
+		return x.TitleContains
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+type CompleteTasksMatchingRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *TaskFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	DryRun bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // solo lista las tareas que se completarían
+	// IDs de la vista previa: si el filtro ya no selecciona exactamente esas
+	// tareas, no se modifica nada (FAILED_PRECONDITION). Vacío: sin comprobar
+	ExpectedIds   []string `protobuf:"bytes,4,rep,name=expected_ids,json=expectedIds,proto3" json:"expected_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTasksMatchingRequest) Reset() {
+	*x = CompleteTasksMatchingRequest{}
+	mi := &file_task_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTasksMatchingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTasksMatchingRequest) ProtoMessage() {}
+
+func (x *CompleteTasksMatchingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTasksMatchingRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTasksMatchingRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CompleteTasksMatchingRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CompleteTasksMatchingRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CompleteTasksMatchingRequest) GetExpectedIds() []string {
+	if x != nil {
+		return x.ExpectedIds
+	}
+	return nil
+}
+
+type DeleteTasksMatchingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *TaskFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	ExpectedIds   []string               `protobuf:"bytes,4,rep,name=expected_ids,json=expectedIds,proto3" json:"expected_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTasksMatchingRequest) Reset() {
+	*x = DeleteTasksMatchingRequest{}
+	mi := &file_task_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTasksMatchingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTasksMatchingRequest) ProtoMessage() {}
+
+func (x *DeleteTasksMatchingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTasksMatchingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTasksMatchingRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteTasksMatchingRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *DeleteTasksMatchingRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DeleteTasksMatchingRequest) GetExpectedIds() []string {
+	if x != nil {
+		return x.ExpectedIds
+	}
+	return nil
+}
+
+// Un mensaje por tarea afectada. Los cambios se confirman en una sola
+// transacción tras el último mensaje: si el stream acaba con error, no se
+// ha aplicado ninguno.
+type TasksMatchingProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Processed     int32                  `protobuf:"varint,2,opt,name=processed,proto3" json:"processed,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TasksMatchingProgress) Reset() {
+	*x = TasksMatchingProgress{}
+	mi := &file_task_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TasksMatchingProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TasksMatchingProgress) ProtoMessage() {}
+
+func (x *TasksMatchingProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TasksMatchingProgress.ProtoReflect.Descriptor instead.
+func (*TasksMatchingProgress) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TasksMatchingProgress) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TasksMatchingProgress) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *TasksMatchingProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_task_proto protoreflect.FileDescriptor
+
+const file_task_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"task.proto\x12\btasks.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x90\x02\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1c\n" +
+	"\tcompleted\x18\x05 \x01(\bR\tcompleted\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"\f_description\"\xaa\x01\n" +
+	"\x11CreateTaskRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12!\n" +
+	"\tcompleted\x18\x04 \x01(\bH\x01R\tcompleted\x88\x01\x01B\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_completed\"8\n" +
+	"\x12CreateTaskResponse\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x0fGetTaskResponse\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"\xb0\x01\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12!\n" +
+	"\tcompleted\x18\x04 \x01(\bH\x02R\tcompleted\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_completed\"8\n" +
+	"\x12UpdateTaskResponse\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"#\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x12DeleteTaskResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\")\n" +
+	"\x17MarkTaskCompleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x18MarkTaskCompleteResponse\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"\x94\x01\n" +
+	"\x16ListTasksByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0einclude_shared\x18\x02 \x01(\bR\rincludeShared\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"Q\n" +
+	"\x13ListAllTasksRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"a\n" +
+	"\x11ListTasksResponse\x12$\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0e.tasks.v1.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb1\x01\n" +
+	"\fCollaborator\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x124\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\x0e2\x14.tasks.v1.PermissionR\n" +
+	"permission\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"z\n" +
+	"\x10ShareTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x124\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\x0e2\x14.tasks.v1.PermissionR\n" +
+	"permission\"O\n" +
+	"\x11ShareTaskResponse\x12:\n" +
+	"\fcollaborator\x18\x01 \x01(\v2\x16.tasks.v1.CollaboratorR\fcollaborator\"F\n" +
+	"\x12UnshareTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"I\n" +
+	"\x13UnshareTaskResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
+	"\x18ListCollaboratorsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"Y\n" +
+	"\x19ListCollaboratorsResponse\x12<\n" +
+	"\rcollaborators\x18\x01 \x03(\v2\x16.tasks.v1.CollaboratorR\rcollaborators\"J\n" +
+	"\x12ExportTasksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tall_users\x18\x02 \x01(\bR\ballUsers\"9\n" +
+	"\x13ExportTasksResponse\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"\x95\x01\n" +
+	"\rImportOptions\x12!\n" +
+	"\fpreserve_ids\x18\x01 \x01(\bR\vpreserveIds\x12/\n" +
+	"\x13preserve_timestamps\x18\x02 \x01(\bR\x12preserveTimestamps\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\"z\n" +
+	"\x12ImportTasksRequest\x123\n" +
+	"\aoptions\x18\x01 \x01(\v2\x17.tasks.v1.ImportOptionsH\x00R\aoptions\x12$\n" +
+	"\x04task\x18\x02 \x01(\v2\x0e.tasks.v1.TaskH\x00R\x04taskB\t\n" +
+	"\apayload\"V\n" +
+	"\vImportError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xa9\x01\n" +
+	"\x13ImportTasksResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12-\n" +
+	"\x06errors\x18\x04 \x03(\v2\x15.tasks.v1.ImportErrorR\x06errors\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"\x95\x02\n" +
+	"\n" +
+	"TaskFilter\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12!\n" +
+	"\tcompleted\x18\x03 \x01(\bH\x00R\tcompleted\x88\x01\x01\x12%\n" +
+	"\x0etitle_contains\x18\x04 \x01(\tR\rtitleContains\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rcreated_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfterB\f\n" +
+	"\n" +
+	"_completed\"\x9e\x01\n" +
+	"\x1cCompleteTasksMatchingRequest\x12,\n" +
+	"\x06filter\x18\x01 \x01(\v2\x14.tasks.v1.TaskFilterR\x06filter\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12!\n" +
+	"\fexpected_ids\x18\x04 \x03(\tR\vexpectedIdsJ\x04\b\x03\x10\x04R\x0eexpected_count\"\x9c\x01\n" +
+	"\x1aDeleteTasksMatchingRequest\x12,\n" +
+	"\x06filter\x18\x01 \x01(\v2\x14.tasks.v1.TaskFilterR\x06filter\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12!\n" +
+	"\fexpected_ids\x18\x04 \x03(\tR\vexpectedIdsJ\x04\b\x03\x10\x04R\x0eexpected_count\"o\n" +
+	"\x15TasksMatchingProgress\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\x12\x1c\n" +
+	"\tprocessed\x18\x02 \x01(\x05R\tprocessed\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total*S\n" +
+	"\n" +
+	"Permission\x12\x1a\n" +
+	"\x16PERMISSION_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fPERMISSION_READ\x10\x01\x12\x14\n" +
+	"\x10PERMISSION_WRITE\x10\x022\xc0\f\n" +
+	"\vTaskService\x12]\n" +
+	"\n" +
+	"CreateTask\x12\x1b.tasks.v1.CreateTaskRequest\x1a\x1c.tasks.v1.CreateTaskResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/tasks\x12V\n" +
+	"\aGetTask\x12\x18.tasks.v1.GetTaskRequest\x1a\x19.tasks.v1.GetTaskResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/tasks/{id}\x12b\n" +
+	"\n" +
+	"UpdateTask\x12\x1b.tasks.v1.UpdateTaskRequest\x1a\x1c.tasks.v1.UpdateTaskResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/v1/tasks/{id}\x12_\n" +
+	"\n" +
+	"DeleteTask\x12\x1b.tasks.v1.DeleteTaskRequest\x1a\x1c.tasks.v1.DeleteTaskResponse\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/tasks/{id}\x12}\n" +
+	"\x10MarkTaskComplete\x12!.tasks.v1.MarkTaskCompleteRequest\x1a\".tasks.v1.MarkTaskCompleteResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/tasks/{id}:complete\x12s\n" +
+	"\x0fListTasksByUser\x12 .tasks.v1.ListTasksByUserRequest\x1a\x1b.tasks.v1.ListTasksResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/users/{user_id}/tasks\x12]\n" +
+	"\fListAllTasks\x12\x1d.tasks.v1.ListAllTasksRequest\x1a\x1b.tasks.v1.ListTasksResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/tasks\x12f\n" +
+	"\vExportTasks\x12\x1c.tasks.v1.ExportTasksRequest\x1a\x1d.tasks.v1.ExportTasksResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/tasks:export0\x01\x12i\n" +
+	"\vImportTasks\x12\x1c.tasks.v1.ImportTasksRequest\x1a\x1d.tasks.v1.ImportTasksResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/tasks:import(\x01\x12\x89\x01\n" +
+	"\x15CompleteTasksMatching\x12&.tasks.v1.CompleteTasksMatchingRequest\x1a\x1f.tasks.v1.TasksMatchingProgress\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/tasks:completeMatching0\x01\x12\x83\x01\n" +
+	"\x13DeleteTasksMatching\x12$.tasks.v1.DeleteTasksMatchingRequest\x1a\x1f.tasks.v1.TasksMatchingProgress\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/tasks:deleteMatching0\x01\x12r\n" +
+	"\tShareTask\x12\x1a.tasks.v1.ShareTaskRequest\x1a\x1b.tasks.v1.ShareTaskResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/tasks/{task_id}/collaborators\x12\x7f\n" +
+	"\vUnshareTask\x12\x1c.tasks.v1.UnshareTaskRequest\x1a\x1d.tasks.v1.UnshareTaskResponse\"3\x82\xd3\xe4\x93\x02-*+/v1/tasks/{task_id}/collaborators/{user_id}\x12\x87\x01\n" +
+	"\x11ListCollaborators\x12\".tasks.v1.ListCollaboratorsRequest\x1a#.tasks.v1.ListCollaboratorsResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/tasks/{task_id}/collaboratorsB5Z3github.com/Mayer-04/grpc-task-manager-go/pkg/taskpbb\x06proto3"
+
+var (
+	file_task_proto_rawDescOnce sync.Once
+	file_task_proto_rawDescData []byte
+)
+
+func file_task_proto_rawDescGZIP() []byte {
+	file_task_proto_rawDescOnce.Do(func() {
+		file_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_task_proto_rawDesc), len(file_task_proto_rawDesc)))
+	})
+	return file_task_proto_rawDescData
+}
+
+var file_task_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_task_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_task_proto_goTypes = []any{
+	(Permission)(0),                      // 0: tasks.v1.Permission
+	(*Task)(nil),                         // 1: tasks.v1.Task
+	(*CreateTaskRequest)(nil),            // 2: tasks.v1.CreateTaskRequest
+	(*CreateTaskResponse)(nil),           // 3: tasks.v1.CreateTaskResponse
+	(*GetTaskRequest)(nil),               // 4: tasks.v1.GetTaskRequest
+	(*GetTaskResponse)(nil),              // 5: tasks.v1.GetTaskResponse
+	(*UpdateTaskRequest)(nil),            // 6: tasks.v1.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),           // 7: tasks.v1.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),            // 8: tasks.v1.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),           // 9: tasks.v1.DeleteTaskResponse
+	(*MarkTaskCompleteRequest)(nil),      // 10: tasks.v1.MarkTaskCompleteRequest
+	(*MarkTaskCompleteResponse)(nil),     // 11: tasks.v1.MarkTaskCompleteResponse
+	(*ListTasksByUserRequest)(nil),       // 12: tasks.v1.ListTasksByUserRequest
+	(*ListAllTasksRequest)(nil),          // 13: tasks.v1.ListAllTasksRequest
+	(*ListTasksResponse)(nil),            // 14: tasks.v1.ListTasksResponse
+	(*Collaborator)(nil),                 // 15: tasks.v1.Collaborator
+	(*ShareTaskRequest)(nil),             // 16: tasks.v1.ShareTaskRequest
+	(*ShareTaskResponse)(nil),            // 17: tasks.v1.ShareTaskResponse
+	(*UnshareTaskRequest)(nil),           // 18: tasks.v1.UnshareTaskRequest
+	(*UnshareTaskResponse)(nil),          // 19: tasks.v1.UnshareTaskResponse
+	(*ListCollaboratorsRequest)(nil),     // 20: tasks.v1.ListCollaboratorsRequest
+	(*ListCollaboratorsResponse)(nil),    // 21: tasks.v1.ListCollaboratorsResponse
+	(*ExportTasksRequest)(nil),           // 22: tasks.v1.ExportTasksRequest
+	(*ExportTasksResponse)(nil),          // 23: tasks.v1.ExportTasksResponse
+	(*ImportOptions)(nil),                // 24: tasks.v1.ImportOptions
+	(*ImportTasksRequest)(nil),           // 25: tasks.v1.ImportTasksRequest
+	(*ImportError)(nil),                  // 26: tasks.v1.ImportError
+	(*ImportTasksResponse)(nil),          // 27: tasks.v1.ImportTasksResponse
+	(*TaskFilter)(nil),                   // 28: tasks.v1.TaskFilter
+	(*CompleteTasksMatchingRequest)(nil), // 29: tasks.v1.CompleteTasksMatchingRequest
+	(*DeleteTasksMatchingRequest)(nil),   // 30: tasks.v1.DeleteTasksMatchingRequest
+	(*TasksMatchingProgress)(nil),        // 31: tasks.v1.TasksMatchingProgress
+	(*timestamppb.Timestamp)(nil),        // 32: google.protobuf.Timestamp
+}
+var file_task_proto_depIdxs = []int32{
+	32, // 0: tasks.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	32, // 1: tasks.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: tasks.v1.CreateTaskResponse.task:type_name -> tasks.v1.Task
+	1,  // 3: tasks.v1.GetTaskResponse.task:type_name -> tasks.v1.Task
+	1,  // 4: tasks.v1.UpdateTaskResponse.task:type_name -> tasks.v1.Task
+	1,  // 5: tasks.v1.MarkTaskCompleteResponse.task:type_name -> tasks.v1.Task
+	1,  // 6: tasks.v1.ListTasksResponse.tasks:type_name -> tasks.v1.Task
+	0,  // 7: tasks.v1.Collaborator.permission:type_name -> tasks.v1.Permission
+	32, // 8: tasks.v1.Collaborator.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 9: tasks.v1.ShareTaskRequest.permission:type_name -> tasks.v1.Permission
+	15, // 10: tasks.v1.ShareTaskResponse.collaborator:type_name -> tasks.v1.Collaborator
+	15, // 11: tasks.v1.ListCollaboratorsResponse.collaborators:type_name -> tasks.v1.Collaborator
+	1,  // 12: tasks.v1.ExportTasksResponse.task:type_name -> tasks.v1.Task
+	24, // 13: tasks.v1.ImportTasksRequest.options:type_name -> tasks.v1.ImportOptions
+	1,  // 14: tasks.v1.ImportTasksRequest.task:type_name -> tasks.v1.Task
+	26, // 15: tasks.v1.ImportTasksResponse.errors:type_name -> tasks.v1.ImportError
+	32, // 16: tasks.v1.TaskFilter.created_before:type_name -> google.protobuf.Timestamp
+	32, // 17: tasks.v1.TaskFilter.created_after:type_name -> google.protobuf.Timestamp
+	28, // 18: tasks.v1.CompleteTasksMatchingRequest.filter:type_name -> tasks.v1.TaskFilter
+	28, // 19: tasks.v1.DeleteTasksMatchingRequest.filter:type_name -> tasks.v1.TaskFilter
+	1,  // 20: tasks.v1.TasksMatchingProgress.task:type_name -> tasks.v1.Task
+	2,  // 21: tasks.v1.TaskService.CreateTask:input_type -> tasks.v1.CreateTaskRequest
+	4,  // 22: tasks.v1.TaskService.GetTask:input_type -> tasks.v1.GetTaskRequest
+	6,  // 23: tasks.v1.TaskService.UpdateTask:input_type -> tasks.v1.UpdateTaskRequest
+	8,  // 24: tasks.v1.TaskService.DeleteTask:input_type -> tasks.v1.DeleteTaskRequest
+	10, // 25: tasks.v1.TaskService.MarkTaskComplete:input_type -> tasks.v1.MarkTaskCompleteRequest
+	12, // 26: tasks.v1.TaskService.ListTasksByUser:input_type -> tasks.v1.ListTasksByUserRequest
+	13, // 27: tasks.v1.TaskService.ListAllTasks:input_type -> tasks.v1.ListAllTasksRequest
+	22, // 28: tasks.v1.TaskService.ExportTasks:input_type -> tasks.v1.ExportTasksRequest
+	25, // 29: tasks.v1.TaskService.ImportTasks:input_type -> tasks.v1.ImportTasksRequest
+	29, // 30: tasks.v1.TaskService.CompleteTasksMatching:input_type -> tasks.v1.CompleteTasksMatchingRequest
+	30, // 31: tasks.v1.TaskService.DeleteTasksMatching:input_type -> tasks.v1.DeleteTasksMatchingRequest
+	16, // 32: tasks.v1.TaskService.ShareTask:input_type -> tasks.v1.ShareTaskRequest
+	18, // 33: tasks.v1.TaskService.UnshareTask:input_type -> tasks.v1.UnshareTaskRequest
+	20, // 34: tasks.v1.TaskService.ListCollaborators:input_type -> tasks.v1.ListCollaboratorsRequest
+	3,  // 35: tasks.v1.TaskService.CreateTask:output_type -> tasks.v1.CreateTaskResponse
+	5,  // 36: tasks.v1.TaskService.GetTask:output_type -> tasks.v1.GetTaskResponse
+	7,  // 37: tasks.v1.TaskService.UpdateTask:output_type -> tasks.v1.UpdateTaskResponse
+	9,  // 38: tasks.v1.TaskService.DeleteTask:output_type -> tasks.v1.DeleteTaskResponse
+	11, // 39: tasks.v1.TaskService.MarkTaskComplete:output_type -> tasks.v1.MarkTaskCompleteResponse
+	14, // 40: tasks.v1.TaskService.ListTasksByUser:output_type -> tasks.v1.ListTasksResponse
+	14, // 41: tasks.v1.TaskService.ListAllTasks:output_type -> tasks.v1.ListTasksResponse
+	23, // 42: tasks.v1.TaskService.ExportTasks:output_type -> tasks.v1.ExportTasksResponse
+	27, // 43: tasks.v1.TaskService.ImportTasks:output_type -> tasks.v1.ImportTasksResponse
+	31, // 44: tasks.v1.TaskService.CompleteTasksMatching:output_type -> tasks.v1.TasksMatchingProgress
+	31, // 45: tasks.v1.TaskService.DeleteTasksMatching:output_type -> tasks.v1.TasksMatchingProgress
+	17, // 46: tasks.v1.TaskService.ShareTask:output_type -> tasks.v1.ShareTaskResponse
+	19, // 47: tasks.v1.TaskService.UnshareTask:output_type -> tasks.v1.UnshareTaskResponse
+	21, // 48: tasks.v1.TaskService.ListCollaborators:output_type -> tasks.v1.ListCollaboratorsResponse
+	35, // [35:49] is the sub-list for method output_type
+	21, // [21:35] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_task_proto_init() }
+func file_task_proto_init() {
+	if File_task_proto != nil {
+		return
+	}
+	file_task_proto_msgTypes[0].OneofWrappers = []any{}
+	file_task_proto_msgTypes[1].OneofWrappers = []any{}
+	file_task_proto_msgTypes[5].OneofWrappers = []any{}
+	file_task_proto_msgTypes[24].OneofWrappers = []any{
+		(*ImportTasksRequest_Options)(nil),
+		(*ImportTasksRequest_Task)(nil),
+	}
+	file_task_proto_msgTypes[27].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_task_proto_rawDesc), len(file_task_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      1,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_task_proto_goTypes,
 		DependencyIndexes: file_task_proto_depIdxs,
+		EnumInfos:         file_task_proto_enumTypes,
 		MessageInfos:      file_task_proto_msgTypes,
 	}.Build()
 	File_task_proto = out.File
@@ -19,13 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TaskService_CreateTask_FullMethodName       = "/tasks.v1.TaskService/CreateTask"
-	TaskService_GetTask_FullMethodName          = "/tasks.v1.TaskService/GetTask"
-	TaskService_UpdateTask_FullMethodName       = "/tasks.v1.TaskService/UpdateTask"
-	TaskService_DeleteTask_FullMethodName       = "/tasks.v1.TaskService/DeleteTask"
-	TaskService_MarkTaskComplete_FullMethodName = "/tasks.v1.TaskService/MarkTaskComplete"
-	TaskService_ListTasksByUser_FullMethodName  = "/tasks.v1.TaskService/ListTasksByUser"
-	TaskService_ListAllTasks_FullMethodName     = "/tasks.v1.TaskService/ListAllTasks"
+	TaskService_CreateTask_FullMethodName            = "/tasks.v1.TaskService/CreateTask"
+	TaskService_GetTask_FullMethodName               = "/tasks.v1.TaskService/GetTask"
+	TaskService_UpdateTask_FullMethodName            = "/tasks.v1.TaskService/UpdateTask"
+	TaskService_DeleteTask_FullMethodName            = "/tasks.v1.TaskService/DeleteTask"
+	TaskService_MarkTaskComplete_FullMethodName      = "/tasks.v1.TaskService/MarkTaskComplete"
+	TaskService_ListTasksByUser_FullMethodName       = "/tasks.v1.TaskService/ListTasksByUser"
+	TaskService_ListAllTasks_FullMethodName          = "/tasks.v1.TaskService/ListAllTasks"
+	TaskService_ExportTasks_FullMethodName           = "/tasks.v1.TaskService/ExportTasks"
+	TaskService_ImportTasks_FullMethodName           = "/tasks.v1.TaskService/ImportTasks"
+	TaskService_CompleteTasksMatching_FullMethodName = "/tasks.v1.TaskService/CompleteTasksMatching"
+	TaskService_DeleteTasksMatching_FullMethodName   = "/tasks.v1.TaskService/DeleteTasksMatching"
+	TaskService_ShareTask_FullMethodName             = "/tasks.v1.TaskService/ShareTask"
+	TaskService_UnshareTask_FullMethodName           = "/tasks.v1.TaskService/UnshareTask"
+	TaskService_ListCollaborators_FullMethodName     = "/tasks.v1.TaskService/ListCollaborators"
 )
 
 // TaskServiceClient is the client API for TaskService service.
@@ -41,6 +48,13 @@ type TaskServiceClient interface {
 	MarkTaskComplete(ctx context.Context, in *MarkTaskCompleteRequest, opts ...grpc.CallOption) (*MarkTaskCompleteResponse, error)
 	ListTasksByUser(ctx context.Context, in *ListTasksByUserRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
 	ListAllTasks(ctx context.Context, in *ListAllTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	ExportTasks(ctx context.Context, in *ExportTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTasksResponse], error)
+	ImportTasks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTasksRequest, ImportTasksResponse], error)
+	CompleteTasksMatching(ctx context.Context, in *CompleteTasksMatchingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TasksMatchingProgress], error)
+	DeleteTasksMatching(ctx context.Context, in *DeleteTasksMatchingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TasksMatchingProgress], error)
+	ShareTask(ctx context.Context, in *ShareTaskRequest, opts ...grpc.CallOption) (*ShareTaskResponse, error)
+	UnshareTask(ctx context.Context, in *UnshareTaskRequest, opts ...grpc.CallOption) (*UnshareTaskResponse, error)
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
 }
 
 type taskServiceClient struct {
@@ -121,6 +135,106 @@ func (c *taskServiceClient) ListAllTasks(ctx context.Context, in *ListAllTasksRe
 	return out, nil
 }
 
+func (c *taskServiceClient) ExportTasks(ctx context.Context, in *ExportTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTasksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[0], TaskService_ExportTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportTasksRequest, ExportTasksResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_ExportTasksClient = grpc.ServerStreamingClient[ExportTasksResponse]
+
+func (c *taskServiceClient) ImportTasks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTasksRequest, ImportTasksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[1], TaskService_ImportTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportTasksRequest, ImportTasksResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_ImportTasksClient = grpc.ClientStreamingClient[ImportTasksRequest, ImportTasksResponse]
+
+func (c *taskServiceClient) CompleteTasksMatching(ctx context.Context, in *CompleteTasksMatchingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TasksMatchingProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[2], TaskService_CompleteTasksMatching_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CompleteTasksMatchingRequest, TasksMatchingProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_CompleteTasksMatchingClient = grpc.ServerStreamingClient[TasksMatchingProgress]
+
+func (c *taskServiceClient) DeleteTasksMatching(ctx context.Context, in *DeleteTasksMatchingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TasksMatchingProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[3], TaskService_DeleteTasksMatching_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DeleteTasksMatchingRequest, TasksMatchingProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_DeleteTasksMatchingClient = grpc.ServerStreamingClient[TasksMatchingProgress]
+
+func (c *taskServiceClient) ShareTask(ctx context.Context, in *ShareTaskRequest, opts ...grpc.CallOption) (*ShareTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareTaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_ShareTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) UnshareTask(ctx context.Context, in *UnshareTaskRequest, opts ...grpc.CallOption) (*UnshareTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareTaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_UnshareTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, TaskService_ListCollaborators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServiceServer is the server API for TaskService service.
 // All implementations must embed UnimplementedTaskServiceServer
 // for forward compatibility.
@@ -134,6 +248,13 @@ type TaskServiceServer interface {
 	MarkTaskComplete(context.Context, *MarkTaskCompleteRequest) (*MarkTaskCompleteResponse, error)
 	ListTasksByUser(context.Context, *ListTasksByUserRequest) (*ListTasksResponse, error)
 	ListAllTasks(context.Context, *ListAllTasksRequest) (*ListTasksResponse, error)
+	ExportTasks(*ExportTasksRequest, grpc.ServerStreamingServer[ExportTasksResponse]) error
+	ImportTasks(grpc.ClientStreamingServer[ImportTasksRequest, ImportTasksResponse]) error
+	CompleteTasksMatching(*CompleteTasksMatchingRequest, grpc.ServerStreamingServer[TasksMatchingProgress]) error
+	DeleteTasksMatching(*DeleteTasksMatchingRequest, grpc.ServerStreamingServer[TasksMatchingProgress]) error
+	ShareTask(context.Context, *ShareTaskRequest) (*ShareTaskResponse, error)
+	UnshareTask(context.Context, *UnshareTaskRequest) (*UnshareTaskResponse, error)
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
 	mustEmbedUnimplementedTaskServiceServer()
 }
 
@@ -165,6 +286,27 @@ func (UnimplementedTaskServiceServer) ListTasksByUser(context.Context, *ListTask
 func (UnimplementedTaskServiceServer) ListAllTasks(context.Context, *ListAllTasksRequest) (*ListTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllTasks not implemented")
 }
+func (UnimplementedTaskServiceServer) ExportTasks(*ExportTasksRequest, grpc.ServerStreamingServer[ExportTasksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) ImportTasks(grpc.ClientStreamingServer[ImportTasksRequest, ImportTasksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) CompleteTasksMatching(*CompleteTasksMatchingRequest, grpc.ServerStreamingServer[TasksMatchingProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method CompleteTasksMatching not implemented")
+}
+func (UnimplementedTaskServiceServer) DeleteTasksMatching(*DeleteTasksMatchingRequest, grpc.ServerStreamingServer[TasksMatchingProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method DeleteTasksMatching not implemented")
+}
+func (UnimplementedTaskServiceServer) ShareTask(context.Context, *ShareTaskRequest) (*ShareTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareTask not implemented")
+}
+func (UnimplementedTaskServiceServer) UnshareTask(context.Context, *UnshareTaskRequest) (*UnshareTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareTask not implemented")
+}
+func (UnimplementedTaskServiceServer) ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollaborators not implemented")
+}
 func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
 func (UnimplementedTaskServiceServer) testEmbeddedByValue()                     {}
 
@@ -312,6 +454,100 @@ func _TaskService_ListAllTasks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_ExportTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).ExportTasks(m, &grpc.GenericServerStream[ExportTasksRequest, ExportTasksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_ExportTasksServer = grpc.ServerStreamingServer[ExportTasksResponse]
+
+func _TaskService_ImportTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskServiceServer).ImportTasks(&grpc.GenericServerStream[ImportTasksRequest, ImportTasksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_ImportTasksServer = grpc.ClientStreamingServer[ImportTasksRequest, ImportTasksResponse]
+
+func _TaskService_CompleteTasksMatching_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CompleteTasksMatchingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).CompleteTasksMatching(m, &grpc.GenericServerStream[CompleteTasksMatchingRequest, TasksMatchingProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_CompleteTasksMatchingServer = grpc.ServerStreamingServer[TasksMatchingProgress]
+
+func _TaskService_DeleteTasksMatching_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeleteTasksMatchingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).DeleteTasksMatching(m, &grpc.GenericServerStream[DeleteTasksMatchingRequest, TasksMatchingProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_DeleteTasksMatchingServer = grpc.ServerStreamingServer[TasksMatchingProgress]
+
+func _TaskService_ShareTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ShareTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ShareTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ShareTask(ctx, req.(*ShareTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_UnshareTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).UnshareTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_UnshareTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).UnshareTask(ctx, req.(*UnshareTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ListCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -347,7 +583,40 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAllTasks",
 			Handler:    _TaskService_ListAllTasks_Handler,
 		},
+		{
+			MethodName: "ShareTask",
+			Handler:    _TaskService_ShareTask_Handler,
+		},
+		{
+			MethodName: "UnshareTask",
+			Handler:    _TaskService_UnshareTask_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _TaskService_ListCollaborators_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportTasks",
+			Handler:       _TaskService_ExportTasks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportTasks",
+			Handler:       _TaskService_ImportTasks_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CompleteTasksMatching",
+			Handler:       _TaskService_CompleteTasksMatching_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DeleteTasksMatching",
+			Handler:       _TaskService_DeleteTasksMatching_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "task.proto",
 }